@@ -0,0 +1,421 @@
+// Package bindcheck defines an analyzer that reports Bind and Resolve calls
+// the container would reject at run time.
+//
+// The container validates resolvers when they're bound, so mistakes like a
+// resolver that returns the wrong type only show up as an error or a panic from
+// MustBind once the program runs. Most of those checks only depend on types, so
+// this analyzer makes them at compile time instead:
+//
+//   - the bound type T must be a pointer or interface
+//   - the resolver must be a function
+//   - the resolver's first return must implement or be assignable to T
+//   - the resolver's second return, if any, must be an error
+//   - the resolver's parameters must be pointers, interfaces, slices,
+//     Optionals of a pointer or interface, a Lazy or func() (T, error) of any
+//     of those, or parameter structs embedding container.In
+//   - a parameter struct's fields must be exported, be one of the types above
+//     other than a parameter struct, and have valid inject tags
+//
+// Resolvers passed to BindResults must return a results struct embedding
+// container.Out, whose fields must be exported pointers or interfaces. Resolve
+// calls are checked for a T that nothing could ever be bound to.
+// Resolvers passed as an interface, such as any, and types that depend on type
+// parameters can't be known until run time, so they're skipped. The analyzer
+// can be run with go vet using bindcheck/cmd/containervet.
+package bindcheck
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"reflect"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// The import path of the container package whose calls are checked
+const containerPath = "github.com/gobros/container"
+
+// Reports invalid Bind and Resolve calls
+var Analyzer = &analysis.Analyzer{
+	Name:     "bindcheck",
+	Doc:      "report container Bind and Resolve calls that would fail at run time",
+	URL:      "https://pkg.go.dev/github.com/gobros/container/bindcheck",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// Functions that bind a resolver, keyed by name, with the index of the
+// resolver argument
+var bindFuncs = map[string]int{
+	"Bind":             0,
+	"BindInstance":     1,
+	"MustBind":         0,
+	"MustBindInstance": 1,
+	"Provide":          0,
+}
+
+// Functions that bind the fields of a resolver's results struct, keyed by
+// name, with the index of the resolver argument
+var bindResultsFuncs = map[string]int{
+	"BindResults":             0,
+	"BindResultsInstance":     1,
+	"MustBindResults":         0,
+	"MustBindResultsInstance": 1,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	inspect.Preorder([]ast.Node{(*ast.CallExpr)(nil)}, func(node ast.Node) {
+		call := node.(*ast.CallExpr)
+
+		name, bindingType := containerCall(pass.TypesInfo, call)
+		if resolverIdx, ok := bindResultsFuncs[name]; ok {
+			if resolverIdx < len(call.Args) {
+				checkResultsResolver(pass, name, call.Args[resolverIdx])
+			}
+			return
+		}
+		if bindingType == nil {
+			return
+		}
+
+		if resolverIdx, ok := bindFuncs[name]; ok {
+			if resolverIdx < len(call.Args) {
+				checkResolver(pass, name, bindingType, call.Args[resolverIdx])
+			}
+		} else if strings.HasPrefix(name, "Resolve") || strings.HasPrefix(name, "MustResolve") {
+			if !isUnknown(bindingType) && !isPointerOrInterface(bindingType) {
+				pass.Reportf(call.Pos(), "%v[%v]: interface T must be a pointer or interface, nothing can be bound to it",
+					name, typeString(pass, bindingType))
+			}
+		}
+	})
+
+	return nil, nil
+}
+
+// Returns the name of the container function called and the type given for
+// its first type parameter. Returns an empty name if the call isn't to a
+// function from the container package, and a nil type if it isn't generic.
+func containerCall(info *types.Info, call *ast.CallExpr) (string, types.Type) {
+	fun := call.Fun
+	for {
+		paren, ok := fun.(*ast.ParenExpr)
+		if !ok {
+			break
+		}
+		fun = paren.X
+	}
+	switch index := fun.(type) {
+	case *ast.IndexExpr:
+		fun = index.X
+	case *ast.IndexListExpr:
+		fun = index.X
+	}
+
+	var ident *ast.Ident
+	switch fun := fun.(type) {
+	case *ast.Ident:
+		ident = fun
+	case *ast.SelectorExpr:
+		ident = fun.Sel
+	default:
+		return "", nil
+	}
+
+	fn, ok := info.Uses[ident].(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != containerPath {
+		return "", nil
+	}
+	instance, ok := info.Instances[ident]
+	if !ok || instance.TypeArgs.Len() == 0 {
+		return fn.Name(), nil
+	}
+
+	return fn.Name(), instance.TypeArgs.At(0)
+}
+
+// Reports the first problem with a resolver bound to the type, checking in the
+// same order the container does
+func checkResolver(pass *analysis.Pass, name string, bindingType types.Type, resolver ast.Expr) {
+	report := func(message string) {
+		pass.Reportf(resolver.Pos(), "%v[%v]: %v", name, typeString(pass, bindingType), message)
+	}
+
+	resolverType := pass.TypesInfo.TypeOf(resolver)
+	if resolverType == nil || isUnknown(resolverType) {
+		return
+	}
+	if types.IsInterface(resolverType) {
+		// Could hold anything, only known at run time
+		return
+	}
+	signature, ok := resolverType.Underlying().(*types.Signature)
+	if !ok {
+		report("resolver must be a function")
+		return
+	}
+
+	results := signature.Results()
+	if results.Len() == 0 {
+		report("resolver must return a concrete as it's first return")
+		return
+	}
+	if isUnknown(bindingType) {
+		return
+	}
+	if !isPointerOrInterface(bindingType) {
+		report("interface T must be a pointer or interface")
+		return
+	}
+	if first := results.At(0).Type(); !isUnknown(first) {
+		if iface, ok := bindingType.Underlying().(*types.Interface); ok && !types.Implements(first, iface) {
+			report("resolver must return a type that implements the provided interface T")
+			return
+		}
+		if !types.IsInterface(bindingType) && !types.AssignableTo(first, bindingType) {
+			report("resolver must return a type assignable to interface T")
+			return
+		}
+	}
+	errorType := types.Universe.Lookup("error").Type().Underlying().(*types.Interface)
+	if results.Len() >= 2 && !isUnknown(results.At(1).Type()) && !types.Implements(results.At(1).Type(), errorType) {
+		report("resolvers with two or more parameters must return an error as the second parameter")
+		return
+	}
+
+	if message := checkParams(pass, signature); message != "" {
+		report(message)
+	}
+}
+
+// Reports the first problem with a resolver whose results struct fields are
+// bound, checking in the same order the container does
+func checkResultsResolver(pass *analysis.Pass, name string, resolver ast.Expr) {
+	report := func(message string) {
+		pass.Reportf(resolver.Pos(), "%v: %v", name, message)
+	}
+
+	resolverType := pass.TypesInfo.TypeOf(resolver)
+	if resolverType == nil || isUnknown(resolverType) || types.IsInterface(resolverType) {
+		return
+	}
+	signature, ok := resolverType.Underlying().(*types.Signature)
+	if !ok {
+		report("resolver must be a function")
+		return
+	}
+
+	results := signature.Results()
+	if results.Len() == 0 {
+		report("resolver must return a results struct as it's first return")
+		return
+	}
+	resultsType := results.At(0).Type()
+	if isUnknown(resultsType) {
+		return
+	}
+	fields, ok := resultsStructFields(resultsType)
+	if !ok {
+		report("resolver must return a results struct as it's first return")
+		return
+	}
+	errorType := types.Universe.Lookup("error").Type().Underlying().(*types.Interface)
+	if results.Len() >= 2 && !isUnknown(results.At(1).Type()) && !types.Implements(results.At(1).Type(), errorType) {
+		report("resolvers with two or more parameters must return an error as the second parameter")
+		return
+	}
+	for i := 0; i < fields.NumFields(); i++ {
+		field := fields.Field(i)
+		if field.Embedded() && isContainerNamed(field.Type(), "Out") {
+			continue
+		}
+		prefix := fmt.Sprintf("field (%v) of results struct (%v)", field.Name(), typeString(pass, resultsType))
+		if !field.Exported() {
+			report(prefix + " must be exported to be bound")
+			return
+		}
+		if !isUnknown(field.Type()) && !isPointerOrInterface(field.Type()) {
+			report(prefix + " must be a pointer or interface to be bound")
+			return
+		}
+	}
+
+	if message := checkParams(pass, signature); message != "" {
+		report(message)
+	}
+}
+
+// Returns what's wrong with the first of the resolver's parameters the
+// container can't resolve, or an empty string if it can resolve them all
+func checkParams(pass *analysis.Pass, signature *types.Signature) string {
+	params := signature.Params()
+	for i := 0; i < params.Len(); i++ {
+		paramType := params.At(i).Type()
+		if fields, ok := paramStructFields(paramType); ok {
+			if message := checkParamStruct(pass, paramType, fields); message != "" {
+				return message
+			}
+			continue
+		}
+		if !isUnknown(paramType) && !isResolvableParam(paramType) {
+			return "resolver input parameters must all be of type pointer, interface, slice, Optional, Lazy, func() (T, error), or a parameter struct"
+		}
+	}
+	return ""
+}
+
+// Returns the struct of a parameter struct, one that embeds container.In, and
+// whether the type is one
+func paramStructFields(paramType types.Type) (*types.Struct, bool) {
+	return embeddingStructFields(paramType, "In")
+}
+
+// Returns the struct of a results struct, one that embeds container.Out, and
+// whether the type is one
+func resultsStructFields(resultsType types.Type) (*types.Struct, bool) {
+	return embeddingStructFields(resultsType, "Out")
+}
+
+// Returns the struct of a type that embeds the named container type, and
+// whether the type is one
+func embeddingStructFields(typ types.Type, name string) (*types.Struct, bool) {
+	fields, ok := typ.Underlying().(*types.Struct)
+	if !ok {
+		return nil, false
+	}
+	for i := 0; i < fields.NumFields(); i++ {
+		if field := fields.Field(i); field.Embedded() && isContainerNamed(field.Type(), name) {
+			return fields, true
+		}
+	}
+	return nil, false
+}
+
+// Reports whether the type is the named type declared by the container
+// package
+func isContainerNamed(typ types.Type, name string) bool {
+	named, ok := typ.(*types.Named)
+	return ok && isContainerType(named, name)
+}
+
+// Returns what's wrong with the first invalid field of the parameter struct,
+// or an empty string if every field can be filled
+func checkParamStruct(pass *analysis.Pass, paramType types.Type, fields *types.Struct) string {
+	for i := 0; i < fields.NumFields(); i++ {
+		field := fields.Field(i)
+		if field.Embedded() && isContainerNamed(field.Type(), "In") {
+			continue
+		}
+
+		prefix := fmt.Sprintf("field (%v) of parameter struct (%v)", field.Name(), typeString(pass, paramType))
+		if !field.Exported() {
+			return prefix + " must be exported to be filled"
+		}
+		if !isUnknown(field.Type()) && !isResolvableParam(field.Type()) {
+			return prefix + " must be of type pointer, interface, slice, Optional, Lazy, or func() (T, error)"
+		}
+
+		modifiers := strings.Split(reflect.StructTag(fields.Tag(i)).Get("inject"), ",")[1:]
+		for _, modifier := range modifiers {
+			switch modifier {
+			case "optional":
+			case "all":
+				elem := field.Type()
+				if deferred, ok := deferredElem(elem); ok {
+					elem = deferred
+				}
+				if _, ok := elem.Underlying().(*types.Slice); !ok && !isUnknown(elem) {
+					return prefix + " is tagged all but isn't a slice"
+				}
+			default:
+				return fmt.Sprintf("%v has unknown inject modifier (%v)", prefix, modifier)
+			}
+		}
+	}
+	return ""
+}
+
+// Reports whether the container accepts a resolver parameter of the type
+func isResolvableParam(paramType types.Type) bool {
+	if elem, ok := deferredElem(paramType); ok {
+		if _, nested := deferredElem(elem); nested {
+			return false
+		}
+		return isUnknown(elem) || isResolvableParam(elem)
+	}
+	if named, ok := paramType.(*types.Named); ok && isContainerType(named, "Optional") && named.TypeArgs().Len() == 1 {
+		elem := named.TypeArgs().At(0)
+		return isUnknown(elem) || isPointerOrInterface(elem)
+	}
+	switch paramType.Underlying().(type) {
+	case *types.Pointer, *types.Interface, *types.Slice:
+		return true
+	}
+	return false
+}
+
+// Returns the T of a Lazy[T] or func() (T, error) parameter, which is resolved
+// when it's used, and whether the type is one
+func deferredElem(paramType types.Type) (types.Type, bool) {
+	if named, ok := paramType.(*types.Named); ok && isContainerType(named, "Lazy") && named.TypeArgs().Len() == 1 {
+		return named.TypeArgs().At(0), true
+	}
+
+	signature, ok := paramType.(*types.Signature)
+	if !ok || signature.Params().Len() != 0 || signature.Results().Len() != 2 {
+		return nil, false
+	}
+	if !types.Identical(signature.Results().At(1).Type(), types.Universe.Lookup("error").Type()) {
+		return nil, false
+	}
+	return signature.Results().At(0).Type(), true
+}
+
+// Reports whether the named type is the one with the name declared by the
+// container package
+func isContainerType(named *types.Named, name string) bool {
+	obj := named.Obj()
+	return obj.Pkg() != nil && obj.Pkg().Path() == containerPath && obj.Name() == name
+}
+
+// Reports whether the type is a pointer or interface, the only types that can
+// be bound
+func isPointerOrInterface(typ types.Type) bool {
+	switch typ.Underlying().(type) {
+	case *types.Pointer, *types.Interface:
+		return true
+	}
+	return false
+}
+
+// Reports whether the type depends on a type parameter, so can't be known
+// until it's instantiated
+func isUnknown(typ types.Type) bool {
+	switch typ := typ.(type) {
+	case *types.TypeParam:
+		return true
+	case *types.Pointer:
+		return isUnknown(typ.Elem())
+	case *types.Slice:
+		return isUnknown(typ.Elem())
+	case *types.Named:
+		args := typ.TypeArgs()
+		for i := 0; i < args.Len(); i++ {
+			if isUnknown(args.At(i)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Formats a type relative to the package being checked
+func typeString(pass *analysis.Pass, typ types.Type) string {
+	return types.TypeString(typ, types.RelativeTo(pass.Pkg))
+}
@@ -0,0 +1,12 @@
+package bindcheck_test
+
+import (
+	"testing"
+
+	"github.com/gobros/container/bindcheck"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), bindcheck.Analyzer, "a")
+}
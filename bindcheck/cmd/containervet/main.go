@@ -0,0 +1,20 @@
+// Command containervet reports container Bind and Resolve calls that would
+// fail at run time. See the bindcheck package for the checks it makes. Run it
+// on its own:
+//
+//	go run github.com/gobros/container/bindcheck/cmd/containervet ./...
+//
+// or through go vet:
+//
+//	go install github.com/gobros/container/bindcheck/cmd/containervet
+//	go vet -vettool=$(which containervet) ./...
+package main
+
+import (
+	"github.com/gobros/container/bindcheck"
+	"golang.org/x/tools/go/analysis/singlechecker"
+)
+
+func main() {
+	singlechecker.Main(bindcheck.Analyzer)
+}
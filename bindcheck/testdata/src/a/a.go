@@ -0,0 +1,154 @@
+package a
+
+import (
+	"errors"
+
+	"github.com/gobros/container"
+)
+
+type Greeter interface {
+	Greet() string
+}
+
+type English struct{}
+
+func (e *English) Greet() string { return "hello" }
+
+type Config struct{}
+
+func NewEnglish() *English { return &English{} }
+
+func NewEnglishErr() (*English, error) { return &English{}, nil }
+
+func NewConfig() Config { return Config{} }
+
+func NewNothing() {}
+
+func NewBadError() (*English, string) { return &English{}, "" }
+
+func NewWithInt(count int) *English { return &English{} }
+
+func NewWithDeps(greeters []Greeter, config *Config, err error) *English { return &English{} }
+
+func NewWithOptional(greeter container.Optional[Greeter], config container.Optional[*Config]) *English {
+	return &English{}
+}
+
+func NewWithLazy(greeter container.Lazy[Greeter], configs func() ([]*Config, error)) *English {
+	return &English{}
+}
+
+func NewWithBadLazy(config container.Lazy[Config]) *English { return &English{} }
+
+func NewWithBadProvider(config func() *Config) *English { return &English{} }
+
+func NewWithBadOptional(config container.Optional[Config]) *English { return &English{} }
+
+type Params struct {
+	container.In
+
+	Greeters []Greeter `inject:",all"`
+	Config   *Config   `inject:"main,optional"`
+}
+
+func NewWithParams(params Params, greeter Greeter) *English { return &English{} }
+
+type UnexportedParams struct {
+	container.In
+
+	config *Config
+}
+
+func NewWithUnexportedParams(params UnexportedParams) *English { return &English{} }
+
+type BadFieldParams struct {
+	container.In
+
+	Count int
+}
+
+func NewWithBadFieldParams(params BadFieldParams) *English { return &English{} }
+
+type BadTagParams struct {
+	container.In
+
+	Config *Config `inject:",all"`
+}
+
+func NewWithBadTagParams(params BadTagParams) *English { return &English{} }
+
+func NewWithPlainStruct(config Config) *English { return &English{} }
+
+type Results struct {
+	container.Out
+
+	Greeter Greeter
+	Config  *Config `inject:"main"`
+}
+
+func NewResults(params Params) (Results, error) { return Results{}, nil }
+
+type ValueResults struct {
+	container.Out
+
+	Config Config
+}
+
+func NewValueResults() ValueResults { return ValueResults{} }
+
+type UnexportedResults struct {
+	container.Out
+
+	config *Config
+}
+
+func NewUnexportedResults() UnexportedResults { return UnexportedResults{} }
+
+func NewResultsWithInt(count int) Results { return Results{} }
+
+func valid(c *container.Container, resolver any) {
+	_ = container.Bind[Greeter](NewEnglish)
+	_ = container.Bind[*English](NewEnglishErr)
+	_ = container.BindInstance[Greeter](c, NewWithDeps)
+	_ = container.Bind[Greeter](NewWithOptional)
+	_ = container.Bind[Greeter](NewWithLazy)
+	_ = container.Bind[Greeter](NewWithParams)
+	_ = container.BindResults(NewResults)
+	container.MustBindResultsInstance(c, NewResults)
+	_ = container.BindResults(resolver)
+	container.MustBind[Greeter](func() Greeter { return &English{} })
+	_ = container.Bind[Greeter](resolver)
+	_ = container.BindValue[Config](Config{})
+	_, _ = container.Resolve[Greeter]()
+	_, _ = container.ResolveAll[*Config]()
+}
+
+func invalid(c *container.Container) {
+	_ = container.Bind[Greeter]("NewEnglish")                                                  // want `Bind\[Greeter\]: resolver must be a function`
+	_ = container.Bind[Greeter](nil)                                                           // want `Bind\[Greeter\]: resolver must be a function`
+	_ = container.Bind[Greeter](NewNothing)                                                    // want `Bind\[Greeter\]: resolver must return a concrete as it's first return`
+	_ = container.Bind[Config](NewConfig)                                                      // want `Bind\[Config\]: interface T must be a pointer or interface`
+	_ = container.Bind[Greeter](NewConfig)                                                     // want `Bind\[Greeter\]: resolver must return a type that implements the provided interface T`
+	_ = container.Bind[*Config](NewEnglish)                                                    // want `Bind\[\*Config\]: resolver must return a type assignable to interface T`
+	_ = container.Bind[*English](NewBadError)                                                  // want `Bind\[\*English\]: resolvers with two or more parameters must return an error as the second parameter`
+	container.MustBindInstance[Greeter](c, NewWithInt)                                         // want `MustBindInstance\[Greeter\]: resolver input parameters must all be of type pointer, interface, slice, Optional, Lazy, func\(\) \(T, error\), or a parameter struct`
+	_ = container.Bind[Greeter](NewWithBadOptional)                                            // want `Bind\[Greeter\]: resolver input parameters must all be of type pointer, interface, slice, Optional, Lazy, func\(\) \(T, error\), or a parameter struct`
+	_ = container.Bind[Greeter](NewWithBadLazy)                                                // want `Bind\[Greeter\]: resolver input parameters must all be of type`
+	_ = container.Bind[Greeter](NewWithBadProvider)                                            // want `Bind\[Greeter\]: resolver input parameters must all be of type`
+	_ = container.Bind[Greeter](NewWithPlainStruct)                                            // want `Bind\[Greeter\]: resolver input parameters must all be of type .*, or a parameter struct`
+	_ = container.Bind[Greeter](NewWithUnexportedParams)                                       // want `Bind\[Greeter\]: field \(config\) of parameter struct \(UnexportedParams\) must be exported to be filled`
+	_ = container.Bind[Greeter](NewWithBadFieldParams)                                         // want `Bind\[Greeter\]: field \(Count\) of parameter struct \(BadFieldParams\) must be of type pointer`
+	_ = container.Bind[Greeter](NewWithBadTagParams)                                           // want `Bind\[Greeter\]: field \(Config\) of parameter struct \(BadTagParams\) is tagged all but isn't a slice`
+	_ = container.BindResults(NewEnglish)                                                      // want `BindResults: resolver must return a results struct as it's first return`
+	_ = container.BindResults(NewValueResults)                                                 // want `BindResults: field \(Config\) of results struct \(ValueResults\) must be a pointer or interface to be bound`
+	container.MustBindResultsInstance(c, NewUnexportedResults)                                 // want `MustBindResultsInstance: field \(config\) of results struct \(UnexportedResults\) must be exported to be bound`
+	_ = container.BindResults(NewResultsWithInt)                                               // want `BindResults: resolver input parameters must all be of type`
+	_ = container.Provide[*Config](func() (*English, error) { return nil, errors.New("bad") }) // want `Provide\[\*Config\]: resolver must return a type assignable to interface T`
+	_, _ = container.Resolve[Config]()                                                         // want `Resolve\[Config\]: interface T must be a pointer or interface, nothing can be bound to it`
+	_ = container.MustResolveNamed[int]("count")                                               // want `MustResolveNamed\[int\]: interface T must be a pointer or interface, nothing can be bound to it`
+}
+
+func generic[T any](resolver func() T) {
+	_ = container.Bind[T](resolver)
+	_, _ = container.Resolve[T]()
+}
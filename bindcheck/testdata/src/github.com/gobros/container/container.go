@@ -0,0 +1,43 @@
+// Package container stubs the signatures bindcheck looks for
+package container
+
+type Container struct{}
+
+type BindOption func()
+
+type Module struct{}
+
+type ModuleOption func(*Module)
+
+func Bind[T any](resolver any, opts ...BindOption) error { return nil }
+
+func BindInstance[T any](container *Container, resolver any, opts ...BindOption) error { return nil }
+
+func MustBind[T any](resolver any, opts ...BindOption) {}
+
+func MustBindInstance[T any](container *Container, resolver any, opts ...BindOption) {}
+
+func Provide[T any](resolver any, opts ...BindOption) ModuleOption { return nil }
+
+func BindValue[T any](value any, opts ...BindOption) error { return nil }
+
+func Resolve[T any]() (T, error) { return *new(T), nil }
+
+func ResolveAll[T any]() ([]T, error) { return nil, nil }
+
+func MustResolveNamed[T any](name string) T { return *new(T) }
+
+type Optional[T any] struct {
+	Value   T
+	Present bool
+}
+
+type Lazy[T any] struct{}
+
+type In struct{}
+
+type Out struct{}
+
+func BindResults(resolver any, opts ...BindOption) error { return nil }
+
+func MustBindResultsInstance(container *Container, resolver any, opts ...BindOption) {}
@@ -0,0 +1,174 @@
+package container
+
+import (
+	"bytes"
+	"fmt"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Concretes built by singleton or scoped resolvers, keyed by the resolver that
+// built them and how its arguments are resolved. Safe for concurrent use.
+type instanceCache struct {
+	lock sync.Mutex
+	// Binds a resolver function to an instantiated concrete instance
+	resolverToConcreteInstance map[cacheKey]any
+	// Binds a resolver function to a call of it that's still in progress
+	resolverToConstruction map[cacheKey]*construction
+	// Concretes in the order they finished being built
+	constructed []*builtConcrete
+	// Bumped every time the cache is emptied so in progress calls started
+	// before then don't repopulate it
+	generation int
+}
+
+// Identifies the concrete a cached resolver builds. Bindings of the same
+// resolver share a concrete, such as one resolver bound to two interfaces,
+// unless they resolve its arguments from different bindings.
+type cacheKey struct {
+	resolver bindingIdentity
+	// The argument names and optional arguments the resolver was bound with
+	args string
+}
+
+// Returns the key the binding's concrete is cached under
+func (b *binding) cacheKey() cacheKey {
+	var args strings.Builder
+	for i := 0; i < b.resolver.Type().NumIn(); i++ {
+		if name, ok := b.argNames[i]; ok || b.argOptional[i] {
+			fmt.Fprintf(&args, "%d:%q:%t;", i, name, b.argOptional[i])
+		}
+	}
+	return cacheKey{resolver: b.identity(), args: args.String()}
+}
+
+// A concrete built by a cached resolver, along with the cleanup funcs of the
+// binding that built it
+type builtConcrete struct {
+	instance any
+	cleanups []func(any) error
+	// Set once OnStop has been called so the concrete isn't stopped twice, and
+	// cleared when it's started again. Guarded by the lifecycle lock of the
+	// container that built it.
+	stopped bool
+}
+
+// A resolver call that's in progress. Closes done once instance and err are set.
+type construction struct {
+	done     chan struct{}
+	instance any
+	err      error
+	// The ID of the goroutine calling the resolver, 0 if it's unknown
+	builder uint64
+}
+
+// Returns the cached concrete for the binding's resolver, calling build to
+// create it if there isn't one yet. When multiple goroutines ask for the same
+// resolver at once, build is only called by the first and the rest wait for
+// its result.
+func (cache *instanceCache) getOrBuild(bound *binding, build func() (any, error)) (any, error) {
+	resolver := bound.cacheKey()
+
+	cache.lock.Lock()
+	if instance, ok := cache.resolverToConcreteInstance[resolver]; ok {
+		cache.lock.Unlock()
+		return instance, nil
+	}
+	if pending, ok := cache.resolverToConstruction[resolver]; ok {
+		cache.lock.Unlock()
+		// A resolver that resolves itself through the container, rather than
+		// taking it as an argument, would wait on itself forever
+		if pending.builder != 0 && pending.builder == goroutineID() {
+			return nil, fmt.Errorf("resolver with return type (%v) was resolved again while building it, %w", bound.resolver.Type().Out(0), ErrDependencyCycle)
+		}
+		<-pending.done
+		return pending.instance, pending.err
+	}
+
+	if cache.resolverToConstruction == nil {
+		cache.resolverToConstruction = make(map[cacheKey]*construction)
+	}
+	pending := &construction{done: make(chan struct{}), builder: goroutineID()}
+	cache.resolverToConstruction[resolver] = pending
+	generation := cache.generation
+	cache.lock.Unlock()
+
+	// Always release anyone waiting on us, even if build panics
+	finished := false
+	defer func() {
+		if !finished {
+			pending.err = fmt.Errorf("resolver with return type (%v) %w in another resolve", bound.resolver.Type().Out(0), ErrResolverPanicked)
+		}
+
+		cache.lock.Lock()
+		if cache.generation == generation {
+			delete(cache.resolverToConstruction, resolver)
+			if pending.err == nil {
+				if cache.resolverToConcreteInstance == nil {
+					cache.resolverToConcreteInstance = make(map[cacheKey]any)
+				}
+				cache.resolverToConcreteInstance[resolver] = pending.instance
+				cache.constructed = append(cache.constructed, &builtConcrete{instance: pending.instance, cleanups: bound.cleanups})
+			}
+		}
+		cache.lock.Unlock()
+
+		close(pending.done)
+	}()
+
+	pending.instance, pending.err = build()
+	finished = true
+
+	return pending.instance, pending.err
+}
+
+// Returns the ID of the calling goroutine, or 0 if it can't be found. The
+// runtime doesn't expose it, so it's parsed from the start of the goroutine's
+// stack trace, e.g. "goroutine 12 [running]:".
+func goroutineID() uint64 {
+	var buf [64]byte
+	trace := buf[:runtime.Stack(buf[:], false)]
+	fields := bytes.Fields(trace)
+	if len(fields) < 2 {
+		return 0
+	}
+	id, err := strconv.ParseUint(string(fields[1]), 10, 64)
+	if err != nil {
+		return 0
+	}
+	return id
+}
+
+// Drops every cached concrete and returns the ones that were dropped in the
+// order they were built
+func (cache *instanceCache) reset() []*builtConcrete {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	constructed := cache.constructed
+	cache.resolverToConcreteInstance = make(map[cacheKey]any)
+	cache.resolverToConstruction = make(map[cacheKey]*construction)
+	cache.constructed = nil
+	cache.generation++
+
+	return constructed
+}
+
+// Returns the concretes that are currently cached in the order they were built
+func (cache *instanceCache) snapshot() []*builtConcrete {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	return append([]*builtConcrete(nil), cache.constructed...)
+}
+
+// Returns true if the binding's concrete has been built and cached
+func (cache *instanceCache) has(bound *binding) bool {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	_, ok := cache.resolverToConcreteInstance[bound.cacheKey()]
+	return ok
+}
@@ -0,0 +1,206 @@
+package container_test
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gobros/container"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConcurrentFirstResolveCallsResolverOnce(t *testing.T) {
+	// Given
+	c := &container.Container{}
+
+	var calls atomic.Int32
+	err := container.BindInstance[PrimaryIDGiver](c, func() *TestStruct1 {
+		calls.Add(1)
+		time.Sleep(10 * time.Millisecond)
+		return &TestStruct1{}
+	})
+	assert.NoError(t, err)
+
+	// When
+	results := make([]PrimaryIDGiver, 50)
+	errs := make([]error, 50)
+	var wg sync.WaitGroup
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], errs[i] = container.ResolveInstance[PrimaryIDGiver](c)
+		}(i)
+	}
+	wg.Wait()
+
+	// Then
+	assert.Equal(t, int32(1), calls.Load())
+	for i := range results {
+		assert.NoError(t, errs[i])
+		assert.Same(t, results[0], results[i])
+	}
+}
+
+func TestConcurrentFirstResolveSharesError(t *testing.T) {
+	// Given
+	c := &container.Container{}
+
+	var calls atomic.Int32
+	err := container.BindInstance[PrimaryIDGiver](c, func() *TestStruct1 {
+		calls.Add(1)
+		time.Sleep(10 * time.Millisecond)
+		panic("resolver did a bad!")
+	})
+	assert.NoError(t, err)
+
+	// When
+	errs := make([]error, 20)
+	var wg sync.WaitGroup
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = container.ResolveInstance[PrimaryIDGiver](c)
+		}(i)
+	}
+	wg.Wait()
+
+	// Then
+	assert.Equal(t, int32(1), calls.Load())
+	for i := range errs {
+		assert.Error(t, errs[i])
+		assert.Contains(t, errs[i].Error(), "resolver did a bad!")
+	}
+}
+
+func TestConcurrentBindAndResolve(t *testing.T) {
+	// Given
+	c := &container.Container{}
+	err := container.BindInstance[SecondaryIDGiver](c, func() *TestStruct2 { return &TestStruct2{} })
+	assert.NoError(t, err)
+
+	// When
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(3)
+		go func(i int) {
+			defer wg.Done()
+			// Capture i so every resolver is a distinct function value
+			assert.NoError(t, container.BindInstance[PrimaryIDGiver](c, func() *TestStruct1 { return &TestStruct1{InstanceId: i} }))
+		}(i)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, container.BindInstance[IDAggregator](c, NewTestIDAggregatorStruct, container.WithLifetime(container.Transient)))
+		}()
+		go func() {
+			defer wg.Done()
+			_, _ = container.ResolveAllInstance[PrimaryIDGiver](c)
+			_, _ = container.ResolveInstance[IDAggregator](c)
+		}()
+	}
+	wg.Wait()
+
+	// Then
+	all, err := container.ResolveAllInstance[PrimaryIDGiver](c)
+	assert.NoError(t, err)
+	assert.Len(t, all, 20)
+	agg, err := container.ResolveInstance[IDAggregator](c)
+	assert.NoError(t, err)
+	assert.Len(t, agg.GivePrimaryIDs(), 20)
+}
+
+func TestConcurrentScopedResolveCallsResolverOnce(t *testing.T) {
+	// Given
+	c := &container.Container{}
+
+	var calls atomic.Int32
+	err := container.BindInstance[PrimaryIDGiver](c, func() *TestStruct1 {
+		calls.Add(1)
+		time.Sleep(10 * time.Millisecond)
+		return &TestStruct1{}
+	}, container.WithLifetime(container.Scoped))
+	assert.NoError(t, err)
+	scope := c.NewScope()
+
+	// When
+	results := make([]PrimaryIDGiver, 20)
+	var wg sync.WaitGroup
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = container.MustResolveScope[PrimaryIDGiver](scope)
+		}(i)
+	}
+	wg.Wait()
+
+	// Then
+	assert.Equal(t, int32(1), calls.Load())
+	for i := range results {
+		assert.Same(t, results[0], results[i])
+	}
+}
+
+func TestResolverResolvingItselfThroughContainer(t *testing.T) {
+	// Given
+	c := &container.Container{}
+
+	container.MustBindInstance[PrimaryIDGiver](c, func() (*TestStruct1, error) {
+		if _, err := container.ResolveInstance[SecondaryIDGiver](c); err != nil {
+			return nil, err
+		}
+		return &TestStruct1{}, nil
+	})
+	container.MustBindInstance[SecondaryIDGiver](c, func() (*TestStruct2, error) {
+		if _, err := container.ResolveInstance[PrimaryIDGiver](c); err != nil {
+			return nil, err
+		}
+		return &TestStruct2{}, nil
+	})
+
+	// When
+	done := make(chan error)
+	go func() {
+		_, err := container.ResolveInstance[PrimaryIDGiver](c)
+		done <- err
+	}()
+
+	// Then
+	select {
+	case err := <-done:
+		assert.ErrorIs(t, err, container.ErrDependencyCycle)
+	case <-time.After(2 * time.Second):
+		t.Fatal("resolve waited on itself")
+	}
+}
+
+func TestEmptyContainerDuringResolve(t *testing.T) {
+	// Given
+	c := &container.Container{}
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	err := container.BindInstance[PrimaryIDGiver](c, func() *TestStruct1 {
+		close(started)
+		<-release
+		return &TestStruct1{}
+	})
+	assert.NoError(t, err)
+
+	// When
+	done := make(chan error)
+	go func() {
+		_, err := container.ResolveInstance[PrimaryIDGiver](c)
+		done <- err
+	}()
+	<-started
+	container.EmptyContainer(c)
+	close(release)
+
+	// Then
+	assert.NoError(t, <-done)
+	_, err = container.ResolveInstance[PrimaryIDGiver](c)
+	assert.Error(t, err)
+}
@@ -0,0 +1,71 @@
+package container
+
+import "reflect"
+
+// Creates a child container that falls back to this container. Resolving a
+// bound type that has nothing bound in the child resolves it from the parent
+// instead, so singletons built by the parent are shared by all its children.
+// Anything bound in the child shadows the parent's bindings for that bound
+// type, but only for the child. Bindings found in the parent have their
+// dependencies resolved in the parent, so they never see the child's
+// bindings.
+func (container *Container) NewChild() *Container {
+	return &Container{parent: container}
+}
+
+// Returns the parent the container was created from, or nil if it isn't a
+// child container
+func (container *Container) Parent() *Container {
+	return container.parent
+}
+
+// Finds the bindings for the key, in the container or the closest parent that
+// has any. Returns the container they belong to along with a copy of them, so
+// binds on other goroutines can't change them under us.
+func (container *Container) lookup(key bindingKey) (*Container, []*binding) {
+	for current := container; current != nil; current = current.parent {
+		current.lock.RLock()
+		resolvers := append([]*binding(nil), current.bindingToResolver[key]...)
+		current.lock.RUnlock()
+
+		if len(resolvers) > 0 {
+			return current, resolvers
+		}
+	}
+
+	return container, nil
+}
+
+// Attempts to resolve and return all concretes bound to the provided type in
+// the container and every one of its parents as a slice. Unlike ResolveAll,
+// bindings in the child don't shadow those in its parents. Concretes from the
+// outermost parent come first and ones from the provided container come last.
+func ResolveAllHierarchyInstance[T any](container *Container) ([]T, error) {
+	bindingType := getBindingType[T]()
+	key := bindingKey{bindingType: bindingType}
+
+	var hierarchy []*Container
+	for current := container; current != nil; current = current.parent {
+		hierarchy = append([]*Container{current}, hierarchy...)
+	}
+
+	var resolvedInstances []T
+	for _, current := range hierarchy {
+		current.lock.RLock()
+		resolvers := append([]*binding(nil), current.bindingToResolver[key]...)
+		current.lock.RUnlock()
+		resolvers, _ = visibleBindings(resolvers, nil)
+
+		resolved, err := resolveBindings(key, current, resolvers, resolution{})
+		if err != nil {
+			return nil, err
+		}
+		resolvedInstances = append(resolvedInstances, resolved.([]T)...)
+	}
+
+	if len(resolvedInstances) == 0 {
+		return nil, &NotBoundError{Type: bindingType, Path: []reflect.Type{bindingType}}
+	}
+
+	return resolvedInstances, nil
+}
@@ -0,0 +1,199 @@
+package container_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gobros/container"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChildFallsBackToParent(t *testing.T) {
+	// Given
+	parent := &container.Container{}
+	container.MustBindInstance[PrimaryIDGiver](parent, NewTestStruct1)
+	child := parent.NewChild()
+	sibling := parent.NewChild()
+
+	// When
+	fromParent, parentErr := container.ResolveInstance[PrimaryIDGiver](parent)
+	fromChild, childErr := container.ResolveInstance[PrimaryIDGiver](child)
+	fromSibling, siblingErr := container.ResolveInstance[PrimaryIDGiver](sibling)
+
+	// Then
+	assert.NoError(t, parentErr)
+	assert.NoError(t, childErr)
+	assert.NoError(t, siblingErr)
+	assert.Same(t, fromParent, fromChild)
+	assert.Same(t, fromParent, fromSibling)
+	assert.Same(t, parent, child.Parent())
+	assert.Nil(t, parent.Parent())
+}
+
+func TestChildShadowsParent(t *testing.T) {
+	// Given
+	parent := &container.Container{}
+	container.MustBindInstance[PrimaryIDGiver](parent, NewTestStruct1)
+	child := parent.NewChild()
+	container.MustBindInstance[PrimaryIDGiver](child, NewTestStruct2)
+
+	// When
+	fromParent := container.MustResolveAllInstance[PrimaryIDGiver](parent)
+	fromChild := container.MustResolveAllInstance[PrimaryIDGiver](child)
+
+	// Then
+	assert.Len(t, fromParent, 1)
+	assert.Len(t, fromChild, 1)
+	assert.Equal(t, TestStruct1Name, fromParent[0].GivePrimaryID().Name)
+	assert.Equal(t, TestStruct2Name, fromChild[0].GivePrimaryID().Name)
+}
+
+func TestChildParentBindingResolvesInParent(t *testing.T) {
+	// Given
+	parent := &container.Container{}
+	container.MustBindInstance[PrimaryIDGiver](parent, NewTestStruct1)
+	container.MustBindInstance[SecondaryIDGiver](parent, NewTestStruct2)
+	container.MustBindInstance[IDAggregator](parent, NewTestIDAggregatorStruct)
+	child := parent.NewChild()
+	container.MustBindInstance[SecondaryIDGiver](child, func() *TestStruct1 {
+		return &TestStruct1{InstanceId: 42}
+	})
+
+	// When
+	agg, err := container.ResolveInstance[IDAggregator](child)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, TestStruct2Name, agg.GiveSecondaryID().Name)
+	assert.NoError(t, child.Validate())
+}
+
+func TestChildBindingDependsOnParent(t *testing.T) {
+	// Given
+	parent := &container.Container{}
+	container.MustBindInstance[SecondaryIDGiver](parent, NewTestStruct2)
+	child := parent.NewChild()
+	container.MustBindInstance[IDAggregator](child, NewTestIDAggregatorStruct)
+
+	// When
+	agg, err := container.ResolveInstance[IDAggregator](child)
+	_, parentErr := container.ResolveInstance[IDAggregator](parent)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, container.MustResolveInstance[SecondaryIDGiver](parent).GiveSecondaryID(), agg.GiveSecondaryID())
+	assert.ErrorIs(t, parentErr, container.ErrNotBound)
+	assert.NoError(t, child.Validate())
+}
+
+func TestChildNoFalseCycleThroughParent(t *testing.T) {
+	// Given
+	parent := &container.Container{}
+	container.MustBindInstance[PrimaryIDGiver](parent, NewTestStruct1)
+	container.MustBindInstance[SecondaryIDGiver](parent, func(prim PrimaryIDGiver) *TestStruct2 {
+		return NewTestStruct2()
+	})
+	child := parent.NewChild()
+	container.MustBindInstance[PrimaryIDGiver](child, func(sec SecondaryIDGiver) *TestStruct1 {
+		return &TestStruct1{InstanceId: 42}
+	})
+
+	// When
+	prim, err := container.ResolveInstance[PrimaryIDGiver](child)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, 42, prim.GivePrimaryID().Number)
+	assert.NoError(t, child.Validate())
+}
+
+func TestChildCycleInParent(t *testing.T) {
+	// Given
+	parent := &container.Container{}
+	container.MustBindInstance[PrimaryIDGiver](parent, func(sec SecondaryIDGiver) *TestStruct1 {
+		return NewTestStruct1()
+	})
+	container.MustBindInstance[SecondaryIDGiver](parent, func(prim PrimaryIDGiver) *TestStruct2 {
+		return NewTestStruct2()
+	})
+	child := parent.NewChild()
+	container.MustBindInstance[IDAggregator](child, NewTestIDAggregatorStruct)
+
+	// When
+	agg, err := container.ResolveInstance[IDAggregator](child)
+
+	// Then
+	assert.Nil(t, agg)
+	assert.ErrorIs(t, err, container.ErrDependencyCycle)
+	assert.ErrorIs(t, child.Validate(), container.ErrDependencyCycle)
+}
+
+func TestChildValidateMissing(t *testing.T) {
+	// Given
+	parent := &container.Container{}
+	child := parent.NewChild()
+	container.MustBindInstance[IDAggregator](child, NewTestIDAggregatorStruct)
+
+	// When
+	err := child.Validate()
+
+	// Then
+	assert.ErrorIs(t, err, container.ErrNotBound)
+}
+
+func TestResolveAllHierarchy(t *testing.T) {
+	// Given
+	parent := &container.Container{}
+	container.MustBindInstance[PrimaryIDGiver](parent, NewTestStruct1)
+	child := parent.NewChild()
+	grandchild := child.NewChild()
+	container.MustBindInstance[PrimaryIDGiver](grandchild, NewTestStruct2)
+
+	// When
+	all, err := container.ResolveAllHierarchyInstance[PrimaryIDGiver](grandchild)
+	shadowed := container.MustResolveAllInstance[PrimaryIDGiver](grandchild)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Len(t, all, 2)
+	assert.Equal(t, TestStruct1Name, all[0].GivePrimaryID().Name)
+	assert.Equal(t, TestStruct2Name, all[1].GivePrimaryID().Name)
+	assert.Same(t, container.MustResolveInstance[PrimaryIDGiver](parent), all[0])
+	assert.Len(t, shadowed, 1)
+}
+
+func TestResolveAllHierarchyNotBound(t *testing.T) {
+	// Given
+	child := (&container.Container{}).NewChild()
+
+	// When
+	all, err := container.ResolveAllHierarchyInstance[PrimaryIDGiver](child)
+
+	// Then
+	assert.Nil(t, all)
+	assert.ErrorIs(t, err, container.ErrNotBound)
+	assert.Panics(t, func() { container.MustResolveAllHierarchyInstance[PrimaryIDGiver](child) })
+}
+
+func TestChildCloseLeavesParent(t *testing.T) {
+	// Given
+	var closed []string
+	parent := &container.Container{}
+	container.MustBindInstance[*closableA](parent, func() *closableA {
+		return &closableA{closed: &closed}
+	})
+	child := parent.NewChild()
+	container.MustBindInstance[*closableB](child, func(a *closableA) *closableB {
+		return &closableB{closed: &closed}
+	})
+	container.MustResolveInstance[*closableB](child)
+
+	// When
+	childErr := child.Close(context.Background())
+	parentErr := parent.Close(context.Background())
+
+	// Then
+	assert.NoError(t, childErr)
+	assert.NoError(t, parentErr)
+	assert.Equal(t, []string{"b", "a"}, closed)
+}
@@ -0,0 +1,976 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/constant"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// The import path of the container package the Bind calls come from
+const containerPath = "github.com/gobros/container"
+
+// Lifetimes, matching the values of container.Lifetime
+const (
+	lifetimeSingleton = 0
+	lifetimeTransient = 1
+	lifetimeScoped    = 2
+)
+
+// What to generate and where from
+type generateConfig struct {
+	// Directory of the package declaring the bindings
+	dir string
+	// Name of the function declaring the bindings
+	funcName string
+	// Name of the generated struct
+	typeName string
+	// File to write, relative to dir. Skipped when loading the package so a
+	// stale copy can't stop it from type checking.
+	outFile string
+}
+
+// A bound type and name, along with everything bound to it
+type genKey struct {
+	bindingType types.Type
+	name        string
+	// Resolvers bound to the key, in the order Resolve sees them
+	bindings []*genBinding
+	// Unique to the key, used to name generated functions
+	index int
+	// The name of the generated Resolve and ResolveAll methods, without the
+	// prefix
+	methodName string
+}
+
+// A single resolver bound to a key
+type genBinding struct {
+	key       *genKey
+	resolver  *types.Func
+	signature *types.Signature
+	lifetime  int64
+	// Names of the bindings to resolve each argument from, keyed by index
+	argNames map[int]string
+	// Arguments left empty when nothing is bound, keyed by index
+	argOptional map[int]bool
+	// Unique to the binding, used to name generated functions
+	index int
+	// Shared by singleton bindings of the same resolver with the same
+	// argument names and optional arguments, which share their concrete the
+	// same way they do in the container. Used to name the fields caching it.
+	singleton int
+	// Where the bind call is, for errors
+	pos token.Position
+}
+
+// Everything learned about the bindings declared in a package
+type bindingSet struct {
+	fset *token.FileSet
+	pkg  *types.Package
+	info *types.Info
+	// Keys in the order they were first bound, so output is stable
+	keys   []*genKey
+	byKey  map[string]*genKey
+	nextID int
+}
+
+// Generates the wiring code for the bindings declared by the config
+func generate(config generateConfig) ([]byte, error) {
+	fset, files, pkg, info, err := loadPackage(config.dir, config.outFile)
+	if err != nil {
+		return nil, err
+	}
+
+	decl := findFunc(files, config.funcName)
+	if decl == nil {
+		return nil, fmt.Errorf("no function (%v) with a body in package (%v)", config.funcName, pkg.Name())
+	}
+
+	set := &bindingSet{fset: fset, pkg: pkg, info: info, byKey: make(map[string]*genKey)}
+	for _, stmt := range decl.Body.List {
+		if err := set.addStatement(stmt); err != nil {
+			return nil, err
+		}
+	}
+	if len(set.keys) == 0 {
+		return nil, fmt.Errorf("function (%v) doesn't bind anything", config.funcName)
+	}
+
+	if err := set.verify(); err != nil {
+		return nil, err
+	}
+	if err := set.nameMethods(); err != nil {
+		return nil, err
+	}
+
+	return set.emit(config)
+}
+
+// Parses and type checks the package in the directory, skipping the file the
+// output is written to and any test files
+func loadPackage(dir string, skipFile string) (*token.FileSet, []*ast.File, *types.Package, *types.Info, error) {
+	buildPkg, err := build.ImportDir(dir, 0)
+	if err != nil {
+		return nil, nil, nil, nil, fmt.Errorf("failed to find package in (%v): %w", dir, err)
+	}
+
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, name := range buildPkg.GoFiles {
+		if name == skipFile {
+			continue
+		}
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.ParseComments)
+		if err != nil {
+			return nil, nil, nil, nil, err
+		}
+		files = append(files, file)
+	}
+
+	info := &types.Info{
+		Types: make(map[ast.Expr]types.TypeAndValue),
+		Uses:  make(map[*ast.Ident]types.Object),
+		Defs:  make(map[*ast.Ident]types.Object),
+	}
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	pkg, err := conf.Check(buildPkg.ImportPath, fset, files, info)
+	if err != nil {
+		return nil, nil, nil, nil, fmt.Errorf("failed to type check package in (%v): %w", dir, err)
+	}
+
+	return fset, files, pkg, info, nil
+}
+
+// Removes any parentheses around the expression
+func unparen(expr ast.Expr) ast.Expr {
+	for {
+		paren, ok := expr.(*ast.ParenExpr)
+		if !ok {
+			return expr
+		}
+		expr = paren.X
+	}
+}
+
+// Returns the top level function with the name, or nil if there isn't one
+func findFunc(files []*ast.File, name string) *ast.FuncDecl {
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			if funcDecl, ok := decl.(*ast.FuncDecl); ok && funcDecl.Recv == nil && funcDecl.Name.Name == name && funcDecl.Body != nil {
+				return funcDecl
+			}
+		}
+	}
+	return nil
+}
+
+// Adds the binding made by a statement in the bindings function. Bind calls
+// can be made on their own, assigned, checked in an if statement, or
+// returned. Returns that don't call anything, such as return nil, are skipped.
+func (set *bindingSet) addStatement(stmt ast.Stmt) error {
+	var expr ast.Expr
+	switch stmt := stmt.(type) {
+	case *ast.ExprStmt:
+		expr = stmt.X
+	case *ast.AssignStmt:
+		if len(stmt.Rhs) == 1 {
+			expr = stmt.Rhs[0]
+		}
+	case *ast.IfStmt:
+		if assign, ok := stmt.Init.(*ast.AssignStmt); ok && len(assign.Rhs) == 1 {
+			expr = assign.Rhs[0]
+		} else if stmt.Init == nil && stmt.Else == nil && isErrorCheck(stmt.Body) {
+			// Checking the error of a Bind call assigned beforehand
+			return nil
+		}
+	case *ast.ReturnStmt:
+		if len(stmt.Results) == 0 {
+			return nil
+		}
+		if len(stmt.Results) == 1 {
+			expr = stmt.Results[0]
+			// Returning nil or an error that was already checked doesn't
+			// bind anything
+			if _, ok := unparen(expr).(*ast.CallExpr); !ok {
+				return nil
+			}
+		}
+	}
+
+	call, ok := unparen(expr).(*ast.CallExpr)
+	if !ok {
+		return set.errorf(stmt, "unsupported statement, only Bind calls are allowed")
+	}
+	return set.addBind(call)
+}
+
+// Returns true if the block only returns, as the body of an if statement
+// that checks an error does
+func isErrorCheck(body *ast.BlockStmt) bool {
+	if len(body.List) != 1 {
+		return false
+	}
+	ret, ok := body.List[0].(*ast.ReturnStmt)
+	if !ok {
+		return false
+	}
+	for _, result := range ret.Results {
+		if _, ok := unparen(result).(*ast.CallExpr); ok {
+			return false
+		}
+	}
+	return true
+}
+
+// Adds the binding made by a Bind call
+func (set *bindingSet) addBind(call *ast.CallExpr) error {
+	switch set.containerFunc(call.Fun) {
+	case "BindResults", "MustBindResults", "BindResultsInstance", "MustBindResultsInstance":
+		return set.errorf(call, "results struct resolvers aren't supported by generated code")
+	}
+	index, ok := unparen(call.Fun).(*ast.IndexExpr)
+	if !ok {
+		return set.errorf(call, "unsupported call, only Bind calls are allowed")
+	}
+	bindFunc := set.containerFunc(index.X)
+	args := call.Args
+	switch bindFunc {
+	case "Bind", "MustBind":
+	case "BindInstance", "MustBindInstance":
+		if len(args) > 0 {
+			args = args[1:]
+		}
+	default:
+		return set.errorf(call, "unsupported call, only Bind, MustBind, BindInstance and MustBindInstance are allowed")
+	}
+	if len(args) == 0 {
+		return set.errorf(call, "missing resolver")
+	}
+
+	bindingType := set.info.Types[index.Index].Type
+	resolver, err := set.resolverFunc(args[0])
+	if err != nil {
+		return err
+	}
+
+	bound := &genBinding{
+		resolver:  resolver,
+		signature: resolver.Type().(*types.Signature),
+		pos:       set.fset.Position(call.Pos()),
+	}
+	var name string
+	for _, opt := range args[1:] {
+		if err := set.applyOption(opt, bound, &name); err != nil {
+			return err
+		}
+	}
+	if err := validateBinding(bindingType, bound); err != nil {
+		return set.errorf(call, "%v", err)
+	}
+
+	set.insert(bindingType, name, bound)
+	return nil
+}
+
+// Returns the name of the container package function the expression refers
+// to, or an empty string if it doesn't refer to one
+func (set *bindingSet) containerFunc(expr ast.Expr) string {
+	var ident *ast.Ident
+	switch expr := unparen(expr).(type) {
+	case *ast.Ident:
+		ident = expr
+	case *ast.SelectorExpr:
+		ident = expr.Sel
+	default:
+		return ""
+	}
+
+	fn, ok := set.info.Uses[ident].(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != containerPath {
+		return ""
+	}
+	return fn.Name()
+}
+
+// Returns the top level function a resolver argument refers to. Func literals
+// and methods aren't supported since the generated code can't refer to them.
+func (set *bindingSet) resolverFunc(expr ast.Expr) (*types.Func, error) {
+	var ident *ast.Ident
+	switch expr := unparen(expr).(type) {
+	case *ast.Ident:
+		ident = expr
+	case *ast.SelectorExpr:
+		ident = expr.Sel
+	}
+
+	var fn *types.Func
+	if ident != nil {
+		fn, _ = set.info.Uses[ident].(*types.Func)
+	}
+	if fn == nil || fn.Type().(*types.Signature).Recv() != nil || fn.Parent() != fn.Pkg().Scope() {
+		return nil, set.errorf(expr, "resolver must be a top level function")
+	}
+	if fn.Type().(*types.Signature).TypeParams() != nil {
+		return nil, set.errorf(expr, "resolver must not be generic")
+	}
+	return fn, nil
+}
+
+// Applies a bind option to the binding. Only options with constant arguments
+// are supported.
+func (set *bindingSet) applyOption(expr ast.Expr, bound *genBinding, name *string) error {
+	call, ok := unparen(expr).(*ast.CallExpr)
+	if !ok {
+		return set.errorf(expr, "unsupported bind option, only WithLifetime, WithName, WithArgName and WithOptionalArg calls are allowed")
+	}
+
+	args := make([]constant.Value, len(call.Args))
+	for idx, arg := range call.Args {
+		args[idx] = set.info.Types[arg].Value
+		if args[idx] == nil {
+			return set.errorf(arg, "bind option arguments must be constant")
+		}
+	}
+
+	switch set.containerFunc(call.Fun) {
+	case "WithLifetime":
+		bound.lifetime, _ = constant.Int64Val(args[0])
+	case "WithName":
+		*name = constant.StringVal(args[0])
+	case "WithArgName":
+		idx, _ := constant.Int64Val(args[0])
+		if bound.argNames == nil {
+			bound.argNames = make(map[int]string)
+		}
+		bound.argNames[int(idx)] = constant.StringVal(args[1])
+	case "WithOptionalArg":
+		idx, _ := constant.Int64Val(args[0])
+		if bound.argOptional == nil {
+			bound.argOptional = make(map[int]bool)
+		}
+		bound.argOptional[int(idx)] = true
+	default:
+		return set.errorf(expr, "unsupported bind option, only WithLifetime, WithName, WithArgName and WithOptionalArg calls are allowed")
+	}
+	return nil
+}
+
+// Checks the resolver can be bound to the bound type, the same way the
+// container does when binding
+func validateBinding(bindingType types.Type, bound *genBinding) error {
+	switch bindingType.Underlying().(type) {
+	case *types.Pointer, *types.Interface:
+	default:
+		return fmt.Errorf("resolver error, interface T must be a pointer or interface")
+	}
+
+	results := bound.signature.Results()
+	if results.Len() == 0 || results.Len() > 2 {
+		return fmt.Errorf("resolver error, resolver must return a concrete and optionally an error")
+	}
+	if !types.AssignableTo(results.At(0).Type(), bindingType) {
+		return fmt.Errorf("resolver error, resolver must return a type assignable to interface T")
+	}
+	errorType := types.Universe.Lookup("error").Type().Underlying().(*types.Interface)
+	if results.Len() == 2 && !types.Implements(results.At(1).Type(), errorType) {
+		return fmt.Errorf("resolver error, resolvers with two or more parameters must return an error as the second parameter")
+	}
+
+	params := bound.signature.Params()
+	if bound.signature.Variadic() {
+		return fmt.Errorf("resolver error, resolver must not be variadic")
+	}
+	for i := 0; i < params.Len(); i++ {
+		paramType := params.At(i).Type()
+		if isDeferred(paramType) {
+			return fmt.Errorf("resolver error, Lazy and func() (T, error) arguments aren't supported by generated code")
+		}
+		if isContext(paramType) {
+			return fmt.Errorf("resolver error, context.Context arguments aren't supported by generated code")
+		}
+		if isParamStruct(paramType) {
+			return fmt.Errorf("resolver error, parameter struct arguments aren't supported by generated code")
+		}
+		if elem, ok := optionalElem(paramType); ok {
+			paramType = elem
+			if _, ok := paramType.Underlying().(*types.Slice); ok {
+				return fmt.Errorf("resolver error, resolver input parameters must all be of type pointer, interface, slice, or Optional")
+			}
+		}
+		switch paramType.Underlying().(type) {
+		case *types.Pointer, *types.Interface, *types.Slice:
+		default:
+			return fmt.Errorf("resolver error, resolver input parameters must all be of type pointer, interface, slice, or Optional")
+		}
+	}
+	for idx := range bound.argNames {
+		if idx < 0 || idx >= params.Len() {
+			return fmt.Errorf("resolver error, named argument index (%v) is out of range for a resolver with (%v) arguments", idx, params.Len())
+		}
+	}
+	for idx := range bound.argOptional {
+		if idx < 0 || idx >= params.Len() {
+			return fmt.Errorf("resolver error, optional argument index (%v) is out of range for a resolver with (%v) arguments", idx, params.Len())
+		}
+	}
+
+	switch bound.lifetime {
+	case lifetimeSingleton, lifetimeTransient:
+	case lifetimeScoped:
+		return fmt.Errorf("resolver error, the Scoped lifetime isn't supported by generated code")
+	default:
+		return fmt.Errorf("resolver error, unknown lifetime (%v)", bound.lifetime)
+	}
+
+	return nil
+}
+
+// Adds a binding to its key. Binding the same resolver again moves it to the
+// end, so it takes precedence, the same way the container does.
+func (set *bindingSet) insert(bindingType types.Type, name string, bound *genBinding) {
+	key := set.key(bindingType, name)
+	for idx, existing := range key.bindings {
+		if existing.resolver == bound.resolver {
+			key.bindings = append(key.bindings[:idx], key.bindings[idx+1:]...)
+			break
+		}
+	}
+
+	bound.key = key
+	bound.index = set.nextID
+	set.nextID++
+	key.bindings = append(key.bindings, bound)
+}
+
+// Returns the key for the bound type and name, creating it if needed
+func (set *bindingSet) key(bindingType types.Type, name string) *genKey {
+	id := types.TypeString(bindingType, nil) + "|" + name
+	key, ok := set.byKey[id]
+	if !ok {
+		key = &genKey{bindingType: bindingType, name: name, index: len(set.keys)}
+		set.byKey[id] = key
+		set.keys = append(set.keys, key)
+	}
+	return key
+}
+
+// Returns the key a resolver argument is resolved from, and whether it's a
+// slice that receives every concrete. Returns a nil key if nothing is bound.
+func (set *bindingSet) argKey(bound *genBinding, idx int) (*genKey, bool) {
+	argType := dependencyType(bound.signature.Params().At(idx).Type())
+	_, all := bound.signature.Params().At(idx).Type().Underlying().(*types.Slice)
+	return set.byKey[types.TypeString(argType, nil)+"|"+bound.argNames[idx]], all
+}
+
+// Returns true if the resolver argument is left empty when nothing is bound,
+// either because it was marked optional or it's an Optional
+func (bound *genBinding) argIsOptional(idx int) bool {
+	_, ok := optionalElem(bound.signature.Params().At(idx).Type())
+	return ok || bound.argOptional[idx]
+}
+
+// Returns the bound type a resolver argument is resolved from. Slice and
+// Optional arguments are resolved from their element type.
+func dependencyType(argType types.Type) types.Type {
+	if slice, ok := argType.Underlying().(*types.Slice); ok {
+		return slice.Elem()
+	}
+	if elem, ok := optionalElem(argType); ok {
+		return elem
+	}
+	return argType
+}
+
+// Returns true if the type is a container.Lazy[T] or a func() (T, error),
+// which the container resolves when they're used
+func isDeferred(typ types.Type) bool {
+	if named, ok := typ.(*types.Named); ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == containerPath && named.Obj().Name() == "Lazy" {
+		return true
+	}
+	signature, ok := typ.(*types.Signature)
+	return ok && signature.Params().Len() == 0 && signature.Results().Len() == 2 &&
+		types.Identical(signature.Results().At(1).Type(), types.Universe.Lookup("error").Type())
+}
+
+// Returns true if the type is a context.Context, which the container passes the
+// context of the resolve rather than resolving
+func isContext(typ types.Type) bool {
+	named, ok := typ.(*types.Named)
+	return ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == "context" && named.Obj().Name() == "Context"
+}
+
+// Returns true if the type is a struct that embeds container.In, which the
+// container fills field by field
+func isParamStruct(typ types.Type) bool {
+	fields, ok := typ.Underlying().(*types.Struct)
+	if !ok {
+		return false
+	}
+	for i := 0; i < fields.NumFields(); i++ {
+		field := fields.Field(i)
+		if named, ok := field.Type().(*types.Named); ok && field.Embedded() &&
+			named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == containerPath && named.Obj().Name() == "In" {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the T of a container.Optional[T], and whether the type is one
+func optionalElem(typ types.Type) (types.Type, bool) {
+	named, ok := typ.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil || named.Obj().Pkg().Path() != containerPath || named.Obj().Name() != "Optional" || named.TypeArgs().Len() != 1 {
+		return nil, false
+	}
+	return named.TypeArgs().At(0), true
+}
+
+// Checks every binding can be resolved, reporting missing dependencies and
+// dependency cycles
+func (set *bindingSet) verify() error {
+	var problems []string
+	for _, key := range set.keys {
+		for _, bound := range key.bindings {
+			for i := 0; i < bound.signature.Params().Len(); i++ {
+				if argKey, all := set.argKey(bound, i); argKey == nil && !all && !bound.argIsOptional(i) {
+					problems = append(problems, fmt.Sprintf("%v: resolver dependency error, nothing bound to dependency (%v) for interface (%v)",
+						bound.pos, set.describeArg(bound, i), set.describeKey(key)))
+				}
+			}
+		}
+	}
+
+	// Depth first search for cycles, each key is only walked once
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[*genKey]int)
+	var path []*genKey
+	var walk func(key *genKey) []*genKey
+	walk = func(key *genKey) []*genKey {
+		switch state[key] {
+		case visiting:
+			for idx, pathKey := range path {
+				if pathKey == key {
+					return append(append([]*genKey(nil), path[idx:]...), key)
+				}
+			}
+		case visited:
+			return nil
+		}
+
+		state[key] = visiting
+		path = append(path, key)
+		for _, bound := range key.bindings {
+			for i := 0; i < bound.signature.Params().Len(); i++ {
+				if argKey, _ := set.argKey(bound, i); argKey != nil {
+					if cycle := walk(argKey); cycle != nil {
+						return cycle
+					}
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		state[key] = visited
+
+		return nil
+	}
+	for _, key := range set.keys {
+		if cycle := walk(key); cycle != nil {
+			names := make([]string, len(cycle))
+			for idx, cycleKey := range cycle {
+				names[idx] = set.describeKey(cycleKey)
+			}
+			problems = append(problems, fmt.Sprintf("dependency cycle detected (%v)", strings.Join(names, " -> ")))
+			break
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid bindings:\n\t%v", strings.Join(problems, "\n\t"))
+	}
+	return nil
+}
+
+// Picks the names of the generated Resolve and ResolveAll methods for every
+// key, based on the bound type's name. Qualifies the names with the package
+// name if they would otherwise clash.
+func (set *bindingSet) nameMethods() error {
+	used := make(map[string][]*genKey)
+	for _, key := range set.keys {
+		key.methodName = exportedIdent(baseTypeName(key.bindingType, false)) + exportedIdent(key.name)
+		used[key.methodName] = append(used[key.methodName], key)
+	}
+
+	for _, clashing := range used {
+		if len(clashing) < 2 {
+			continue
+		}
+		for _, key := range clashing {
+			key.methodName = exportedIdent(baseTypeName(key.bindingType, true)) + exportedIdent(key.name)
+		}
+	}
+
+	seen := make(map[string]*genKey)
+	for _, key := range set.keys {
+		if other, ok := seen[key.methodName]; ok {
+			return fmt.Errorf("can't name methods for both (%v) and (%v), they would both be called Resolve%v", set.describeKey(other), set.describeKey(key), key.methodName)
+		}
+		seen[key.methodName] = key
+	}
+	return nil
+}
+
+// Returns the name of a type with any pointers removed, optionally prefixed
+// with the name of its package
+func baseTypeName(typ types.Type, qualified bool) string {
+	for {
+		pointer, ok := typ.(*types.Pointer)
+		if !ok {
+			break
+		}
+		typ = pointer.Elem()
+	}
+
+	named, ok := typ.(*types.Named)
+	if !ok {
+		return types.TypeString(typ, func(*types.Package) string { return "" })
+	}
+	if qualified && named.Obj().Pkg() != nil {
+		return named.Obj().Pkg().Name() + "_" + named.Obj().Name()
+	}
+	return named.Obj().Name()
+}
+
+// Turns text into an exported Go identifier, e.g. "read-replica" into
+// "ReadReplica"
+func exportedIdent(text string) string {
+	var builder strings.Builder
+	upper := true
+	for _, r := range text {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		builder.WriteRune(r)
+	}
+	return builder.String()
+}
+
+// Formats a key for use in an error, matching how the container formats it
+func (set *bindingSet) describeKey(key *genKey) string {
+	described := types.TypeString(key.bindingType, func(pkg *types.Package) string { return pkg.Name() })
+	if key.name != "" {
+		described += "(name=" + key.name + ")"
+	}
+	return described
+}
+
+// Formats a resolver argument's dependency for use in an error
+func (set *bindingSet) describeArg(bound *genBinding, idx int) string {
+	argType := bound.signature.Params().At(idx).Type()
+	if elem, ok := optionalElem(argType); ok {
+		argType = elem
+	}
+	return set.describeKey(&genKey{bindingType: argType, name: bound.argNames[idx]})
+}
+
+// Returns an error for the node, prefixed with its position
+func (set *bindingSet) errorf(node ast.Node, format string, args ...any) error {
+	return fmt.Errorf("%v: %v", set.fset.Position(node.Pos()), fmt.Sprintf(format, args...))
+}
+
+// Writes the generated code
+type emitter struct {
+	set     *bindingSet
+	builder strings.Builder
+	// Import names keyed by package path
+	imports map[string]string
+	// Import paths keyed by import name
+	importNames map[string]string
+	// Whether the generated code wraps any errors with fmt
+	usesFmt bool
+}
+
+// Numbers the concretes cached for singleton bindings, giving bindings that
+// would share a concrete in the container the same number
+func (set *bindingSet) assignSingletons() {
+	singletons := make(map[string]int)
+	for _, key := range set.keys {
+		for _, bound := range key.bindings {
+			if bound.lifetime != lifetimeSingleton {
+				continue
+			}
+
+			var id strings.Builder
+			id.WriteString(bound.resolver.FullName())
+			for i := 0; i < bound.signature.Params().Len(); i++ {
+				if name, ok := bound.argNames[i]; ok || bound.argOptional[i] {
+					fmt.Fprintf(&id, "|%d:%q:%t", i, name, bound.argOptional[i])
+				}
+			}
+
+			singleton, ok := singletons[id.String()]
+			if !ok {
+				singleton = len(singletons)
+				singletons[id.String()] = singleton
+			}
+			bound.singleton = singleton
+		}
+	}
+}
+
+// Generates the formatted wiring code
+func (set *bindingSet) emit(config generateConfig) ([]byte, error) {
+	e := &emitter{set: set, imports: make(map[string]string), importNames: make(map[string]string)}
+	e.addImport("fmt", "fmt")
+	e.addImport("sync", "sync")
+
+	set.assignSingletons()
+	body := e.body(config)
+	// Only reserved up front so the generated code can always refer to it
+	// as fmt
+	if !e.usesFmt {
+		delete(e.imports, "fmt")
+	}
+
+	var file strings.Builder
+	fmt.Fprintf(&file, "// Code generated by containergen from %v. DO NOT EDIT.\n\n", config.funcName)
+	fmt.Fprintf(&file, "package %v\n\n", set.pkg.Name())
+	paths := make([]string, 0, len(e.imports))
+	for path := range e.imports {
+		paths = append(paths, path)
+	}
+	// Standard library imports first, then everything else, the way goimports
+	// groups them
+	isStd := func(path string) bool {
+		return !strings.Contains(strings.SplitN(path, "/", 2)[0], ".")
+	}
+	sort.Slice(paths, func(i, j int) bool {
+		if isStd(paths[i]) != isStd(paths[j]) {
+			return isStd(paths[i])
+		}
+		return paths[i] < paths[j]
+	})
+	file.WriteString("import (\n")
+	for idx, path := range paths {
+		if idx > 0 && isStd(paths[idx-1]) && !isStd(path) {
+			file.WriteString("\n")
+		}
+		name := e.imports[path]
+		if name == importBaseName(path) {
+			fmt.Fprintf(&file, "\t%q\n", path)
+		} else {
+			fmt.Fprintf(&file, "\t%v %q\n", name, path)
+		}
+	}
+	file.WriteString(")\n\n")
+	file.WriteString(body)
+
+	code, err := format.Source([]byte(file.String()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to format generated code: %w", err)
+	}
+	return code, nil
+}
+
+// Writes everything after the imports
+func (e *emitter) body(config generateConfig) string {
+	set := e.set
+	name := config.typeName
+	w := &e.builder
+
+	fmt.Fprintf(w, "// Builds the bindings declared in %v without reflection. Safe for\n", config.funcName)
+	fmt.Fprintf(w, "// concurrent use.\n")
+	fmt.Fprintf(w, "type %v struct {\n", name)
+	fmt.Fprintf(w, "lock sync.Mutex\n")
+	declared := make(map[int]bool)
+	for _, key := range set.keys {
+		for _, bound := range key.bindings {
+			if bound.lifetime != lifetimeSingleton || declared[bound.singleton] {
+				continue
+			}
+			declared[bound.singleton] = true
+			fmt.Fprintf(w, "// Built by %v\n", e.funcRef(bound.resolver))
+			fmt.Fprintf(w, "singleton%d %v\n", bound.singleton, e.typeRef(bound.signature.Results().At(0).Type()))
+			fmt.Fprintf(w, "built%d bool\n", bound.singleton)
+		}
+	}
+	fmt.Fprintf(w, "}\n\n")
+
+	fmt.Fprintf(w, "// Creates an empty %v. Nothing is built until it's resolved.\n", name)
+	fmt.Fprintf(w, "func New%v() *%v {\n", exportedIdent(name), name)
+	fmt.Fprintf(w, "return &%v{}\n", name)
+	fmt.Fprintf(w, "}\n\n")
+
+	for _, key := range set.keys {
+		keyType := e.typeRef(key.bindingType)
+		described := set.describeKey(key)
+
+		fmt.Fprintf(w, "// Resolves every concrete bound to %v\n", described)
+		fmt.Fprintf(w, "func (c *%v) ResolveAll%v() ([]%v, error) {\n", name, key.methodName, keyType)
+		fmt.Fprintf(w, "c.lock.Lock()\n")
+		fmt.Fprintf(w, "defer c.lock.Unlock()\n\n")
+		fmt.Fprintf(w, "return c.all%d()\n", key.index)
+		fmt.Fprintf(w, "}\n\n")
+
+		fmt.Fprintf(w, "// Resolves the concrete most recently bound to %v\n", described)
+		fmt.Fprintf(w, "func (c *%v) Resolve%v() (%v, error) {\n", name, key.methodName, keyType)
+		fmt.Fprintf(w, "c.lock.Lock()\n")
+		fmt.Fprintf(w, "defer c.lock.Unlock()\n\n")
+		fmt.Fprintf(w, "all, err := c.all%d()\n", key.index)
+		fmt.Fprintf(w, "if err != nil {\n")
+		fmt.Fprintf(w, "var zero %v\n", keyType)
+		fmt.Fprintf(w, "return zero, err\n")
+		fmt.Fprintf(w, "}\n")
+		fmt.Fprintf(w, "return all[len(all)-1], nil\n")
+		fmt.Fprintf(w, "}\n\n")
+	}
+
+	for _, key := range set.keys {
+		keyType := e.typeRef(key.bindingType)
+
+		fmt.Fprintf(w, "func (c *%v) all%d() ([]%v, error) {\n", name, key.index, keyType)
+		fmt.Fprintf(w, "all := make([]%v, 0, %d)\n", keyType, len(key.bindings))
+		for _, bound := range key.bindings {
+			fmt.Fprintf(w, "instance%d, err := c.build%d()\n", bound.index, bound.index)
+			fmt.Fprintf(w, "if err != nil {\n")
+			fmt.Fprintf(w, "return nil, err\n")
+			fmt.Fprintf(w, "}\n")
+			fmt.Fprintf(w, "all = append(all, instance%d)\n", bound.index)
+		}
+		fmt.Fprintf(w, "return all, nil\n")
+		fmt.Fprintf(w, "}\n\n")
+	}
+
+	for _, key := range set.keys {
+		for _, bound := range key.bindings {
+			e.buildFunc(name, bound)
+		}
+	}
+
+	return w.String()
+}
+
+// Writes the function that builds a single binding
+func (e *emitter) buildFunc(name string, bound *genBinding) {
+	set := e.set
+	w := &e.builder
+	described := strconv.Quote(set.describeKey(bound.key))
+	resultType := e.typeRef(bound.signature.Results().At(0).Type())
+	params := bound.signature.Params()
+
+	fmt.Fprintf(w, "func (c *%v) build%d() (%v, error) {\n", name, bound.index, resultType)
+	if bound.lifetime == lifetimeSingleton {
+		fmt.Fprintf(w, "if c.built%d {\n", bound.singleton)
+		fmt.Fprintf(w, "return c.singleton%d, nil\n", bound.singleton)
+		fmt.Fprintf(w, "}\n\n")
+	}
+
+	args := make([]string, params.Len())
+	for i := 0; i < params.Len(); i++ {
+		argKey, all := set.argKey(bound, i)
+		args[i] = fmt.Sprintf("arg%d", i)
+
+		if argKey == nil {
+			// Slices and optional arguments are left empty when nothing is bound
+			fmt.Fprintf(w, "var %v %v\n", args[i], e.typeRef(params.At(i).Type()))
+			continue
+		}
+
+		e.usesFmt = true
+		fmt.Fprintf(w, "%vAll, err := c.all%d()\n", args[i], argKey.index)
+		fmt.Fprintf(w, "if err != nil {\n")
+		fmt.Fprintf(w, "var zero %v\n", resultType)
+		fmt.Fprintf(w, "return zero, fmt.Errorf(\"resolver dependency error, failed to resolve dependency (%%v) for interface (%%v): %%w\", %q, %v, err)\n",
+			set.describeArg(bound, i), described)
+		fmt.Fprintf(w, "}\n")
+		if all {
+			fmt.Fprintf(w, "%v := %vAll\n", args[i], args[i])
+		} else if _, ok := optionalElem(params.At(i).Type()); ok {
+			fmt.Fprintf(w, "%v := %v{Value: %vAll[len(%vAll)-1], Present: true}\n", args[i], e.typeRef(params.At(i).Type()), args[i], args[i])
+		} else {
+			fmt.Fprintf(w, "%v := %vAll[len(%vAll)-1]\n", args[i], args[i], args[i])
+		}
+	}
+
+	call := fmt.Sprintf("%v(%v)", e.funcRef(bound.resolver), strings.Join(args, ", "))
+	if bound.signature.Results().Len() == 2 {
+		e.usesFmt = true
+		fmt.Fprintf(w, "instance, resolverErr := %v\n", call)
+		fmt.Fprintf(w, "if resolverErr != nil {\n")
+		fmt.Fprintf(w, "var zero %v\n", resultType)
+		fmt.Fprintf(w, "return zero, fmt.Errorf(\"failed to resolve for interface (%%v), resolver returned error: %%w\", %v, resolverErr)\n", described)
+		fmt.Fprintf(w, "}\n")
+	} else {
+		fmt.Fprintf(w, "instance := %v\n", call)
+	}
+
+	if bound.lifetime == lifetimeSingleton {
+		fmt.Fprintf(w, "c.singleton%d, c.built%d = instance, true\n", bound.singleton, bound.singleton)
+	}
+	fmt.Fprintf(w, "return instance, nil\n")
+	fmt.Fprintf(w, "}\n\n")
+}
+
+// Returns how to refer to the type in the generated code, adding imports as
+// needed
+func (e *emitter) typeRef(typ types.Type) string {
+	return types.TypeString(typ, e.qualifier)
+}
+
+// Returns how to refer to the function in the generated code, adding imports
+// as needed
+func (e *emitter) funcRef(fn *types.Func) string {
+	if qualifier := e.qualifier(fn.Pkg()); qualifier != "" {
+		return qualifier + "." + fn.Name()
+	}
+	return fn.Name()
+}
+
+// Returns the name to qualify the package's identifiers with, importing it if
+// needed. Returns an empty string for the generated package itself.
+func (e *emitter) qualifier(pkg *types.Package) string {
+	if pkg == e.set.pkg {
+		return ""
+	}
+	return e.addImport(pkg.Path(), pkg.Name())
+}
+
+// Imports the package, returning the name to refer to it by. Picks another
+// name if the package's own name is already taken.
+func (e *emitter) addImport(path string, name string) string {
+	if existing, ok := e.imports[path]; ok {
+		return existing
+	}
+
+	candidate := name
+	for suffix := 2; e.importNames[candidate] != ""; suffix++ {
+		candidate = fmt.Sprintf("%v%d", name, suffix)
+	}
+	e.imports[path] = candidate
+	e.importNames[candidate] = path
+	return candidate
+}
+
+// Returns the last element of an import path, which is usually the package's
+// name
+func importBaseName(path string) string {
+	return path[strings.LastIndex(path, "/")+1:]
+}
@@ -0,0 +1,204 @@
+package main
+
+import (
+	"flag"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gobros/container"
+	"github.com/gobros/container/cmd/containergen/testdata/app"
+	"github.com/stretchr/testify/assert"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden generated file")
+
+func TestGenerate(t *testing.T) {
+	assertGenerateGolden(t, "app")
+}
+
+func TestGenerateWithoutDependencies(t *testing.T) {
+	assertGenerateGolden(t, "simple")
+}
+
+func TestGeneratedCodeCompiles(t *testing.T) {
+	// When
+	methods := checkGenerated(t, "app", "app.go")
+
+	// Then
+	for _, method := range []string{"ResolveDB", "ResolveAllDB", "ResolveDBReplica", "ResolveHandler", "ResolveAllHandler", "ResolveUserHandler", "ResolveServer"} {
+		assert.Contains(t, methods, method)
+	}
+
+	// When
+	methods = checkGenerated(t, "simple", "simple.go")
+
+	// Then
+	for _, method := range []string{"ResolveDB", "ResolveAllDB", "ResolveCache", "ResolveAllCache"} {
+		assert.Contains(t, methods, method)
+	}
+}
+
+// Generates the bindings declared by the Bindings function in the test
+// package, expecting it to match the package's golden generated file
+func assertGenerateGolden(t *testing.T, dir string) {
+	// Given
+	config := generateConfig{dir: filepath.Join("testdata", dir), funcName: "Bindings", typeName: "GeneratedContainer", outFile: "container_gen.go"}
+	golden := filepath.Join(config.dir, config.outFile)
+
+	// When
+	code, err := generate(config)
+
+	// Then
+	assert.NoError(t, err)
+	if *update {
+		assert.NoError(t, os.WriteFile(golden, code, 0o644))
+	}
+	expected, err := os.ReadFile(golden)
+	assert.NoError(t, err)
+	assert.Equal(t, string(expected), string(code))
+}
+
+// Type checks the test package along with its golden generated file, and
+// returns the names of the generated container's methods
+func checkGenerated(t *testing.T, dir string, source string) []string {
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, name := range []string{source, "container_gen.go"} {
+		file, err := parser.ParseFile(fset, filepath.Join("testdata", dir, name), nil, 0)
+		assert.NoError(t, err)
+		files = append(files, file)
+	}
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+
+	pkg, err := conf.Check(dir, fset, files, nil)
+	assert.NoError(t, err)
+	if pkg == nil || pkg.Scope().Lookup("GeneratedContainer") == nil {
+		return nil
+	}
+	generated, _ := pkg.Scope().Lookup("GeneratedContainer").Type().(*types.Named)
+	assert.NotNil(t, generated)
+	methods := make([]string, generated.NumMethods())
+	for idx := range methods {
+		methods[idx] = generated.Method(idx).Name()
+	}
+	return methods
+}
+
+func TestGeneratedCodeMatchesContainer(t *testing.T) {
+	// Given
+	c := &container.Container{}
+	assert.NoError(t, app.Bindings(c))
+	generated := app.NewGeneratedContainer()
+
+	// When
+	server := container.MustResolveInstance[*app.Server](c)
+	generatedServer, err := generated.ResolveServer()
+	assert.NoError(t, err)
+	handlers := container.MustResolveAllInstance[app.Handler](c)
+	generatedHandlers, err := generated.ResolveAllHandler()
+	assert.NoError(t, err)
+	userHandler := container.MustResolveInstance[*app.UserHandler](c)
+	generatedUserHandler, err := generated.ResolveUserHandler()
+	assert.NoError(t, err)
+	replica := container.MustResolveNamedInstance[*app.DB](c, "replica")
+	generatedReplica, err := generated.ResolveDBReplica()
+	assert.NoError(t, err)
+
+	// Then
+	assert.Equal(t, routes(server.Handlers), routes(generatedServer.Handlers))
+	assert.Equal(t, routes(handlers), routes(generatedHandlers))
+	assert.Equal(t, server.Replica.Config.DSN, generatedServer.Replica.Config.DSN)
+	assert.Equal(t, server.Metrics.Present, generatedServer.Metrics.Present)
+	assert.Equal(t, server.Cache == nil, generatedServer.Cache == nil)
+
+	// Singletons are shared the same way, including one resolver bound to
+	// two types, and transients are built every time
+	assert.Equal(t, []string{"/users", "/orders"}, routes(handlers))
+	assert.Same(t, replica, server.Replica)
+	assert.Same(t, generatedReplica, generatedServer.Replica)
+	assert.True(t, handlers[0] == app.Handler(userHandler))
+	assert.True(t, generatedHandlers[0] == app.Handler(generatedUserHandler))
+	assert.True(t, handlers[0] == server.Handlers[0])
+	assert.True(t, generatedHandlers[0] == generatedServer.Handlers[0])
+	assert.False(t, handlers[1] == server.Handlers[1])
+	assert.False(t, generatedHandlers[1] == generatedServer.Handlers[1])
+}
+
+// Returns the route of each handler
+func routes(handlers []app.Handler) []string {
+	routes := make([]string, len(handlers))
+	for idx, handler := range handlers {
+		routes[idx] = handler.Route()
+	}
+	return routes
+}
+
+func TestGenerateMissingDependency(t *testing.T) {
+	assertGenerateError(t, "MissingDependency", "nothing bound to dependency (*invalid.B) for interface (*invalid.A)")
+}
+
+func TestGenerateCycle(t *testing.T) {
+	assertGenerateError(t, "Cycle", "dependency cycle detected (*invalid.A -> *invalid.B -> *invalid.A)")
+}
+
+func TestGenerateScoped(t *testing.T) {
+	assertGenerateError(t, "Scoped", "the Scoped lifetime isn't supported by generated code")
+}
+
+func TestGenerateLazyArgument(t *testing.T) {
+	assertGenerateError(t, "LazyArgument", "Lazy and func() (T, error) arguments aren't supported by generated code")
+}
+
+func TestGenerateContextArgument(t *testing.T) {
+	assertGenerateError(t, "ContextArgument", "context.Context arguments aren't supported by generated code")
+}
+
+func TestGenerateParamStruct(t *testing.T) {
+	assertGenerateError(t, "ParamStruct", "parameter struct arguments aren't supported by generated code")
+}
+
+func TestGenerateResults(t *testing.T) {
+	assertGenerateError(t, "Results", "results struct resolvers aren't supported by generated code")
+}
+
+func TestGenerateUnsupportedStatement(t *testing.T) {
+	assertGenerateError(t, "UnsupportedStatement", "unsupported call, only Bind calls are allowed")
+}
+
+func TestGenerateFuncLiteral(t *testing.T) {
+	assertGenerateError(t, "FuncLiteral", "resolver must be a top level function")
+}
+
+func TestGenerateNonConstantOption(t *testing.T) {
+	assertGenerateError(t, "NonConstantName", "bind option arguments must be constant")
+}
+
+func TestGenerateMissingFunc(t *testing.T) {
+	assertGenerateError(t, "Missing", "no function (Missing) with a body in package (invalid)")
+}
+
+// Generates the bindings declared by a function in the invalid test package,
+// expecting it to fail with the message
+func assertGenerateError(t *testing.T, funcName string, expected string) {
+	// Given
+	config := generateConfig{dir: filepath.Join("testdata", "invalid"), funcName: funcName, typeName: "GeneratedContainer", outFile: "container_gen.go"}
+
+	// When
+	_, err := generate(config)
+
+	// Then
+	assert.Error(t, err)
+	assert.ErrorContains(t, err, expected)
+}
+
+func TestExportedIdent(t *testing.T) {
+	assert.Equal(t, "ReadReplica", exportedIdent("read-replica"))
+	assert.Equal(t, "Primary", exportedIdent("primary"))
+	assert.Equal(t, "DB2", exportedIdent("DB2"))
+}
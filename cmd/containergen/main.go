@@ -0,0 +1,46 @@
+// Command containergen generates plain Go code that wires up the bindings
+// declared in a function, without reflection. The function is read from the
+// package in the given directory and holds a list of Bind calls:
+//
+//	func Bindings(c *container.Container) {
+//		container.MustBindInstance[*sql.DB](c, OpenDB)
+//		container.MustBindInstance[Handler](c, NewUserHandler)
+//		container.MustBindInstance[Handler](c, NewOrderHandler, container.WithLifetime(container.Transient))
+//	}
+//
+// The generated file declares a struct with a Resolve and ResolveAll method
+// for every bound type, which build the same object graph the container would.
+// Singletons are built once, slice arguments receive every concrete bound to
+// their element type, and the most recently bound resolver wins. Missing
+// dependencies and cycles are reported when generating rather than at run
+// time. Typically run with go generate:
+//
+//	//go:generate go run github.com/gobros/container/cmd/containergen -func Bindings
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+func main() {
+	var config generateConfig
+	flag.StringVar(&config.dir, "dir", ".", "directory of the package declaring the bindings")
+	flag.StringVar(&config.funcName, "func", "Bindings", "name of the function declaring the bindings")
+	flag.StringVar(&config.typeName, "type", "GeneratedContainer", "name of the generated struct")
+	flag.StringVar(&config.outFile, "out", "container_gen.go", "file to write, relative to -dir")
+	flag.Parse()
+
+	code, err := generate(config)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "containergen: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := os.WriteFile(filepath.Join(config.dir, config.outFile), code, 0o644); err != nil {
+		fmt.Fprintf(os.Stderr, "containergen: %v\n", err)
+		os.Exit(1)
+	}
+}
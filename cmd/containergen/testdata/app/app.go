@@ -0,0 +1,88 @@
+// Package app declares bindings for the containergen tests
+package app
+
+import (
+	"errors"
+
+	"github.com/gobros/container"
+)
+
+type Config struct {
+	DSN string
+}
+
+type DB struct {
+	Config *Config
+}
+
+type Handler interface {
+	Route() string
+}
+
+type UserHandler struct {
+	DB     *DB
+	Config *Config
+}
+
+func (h *UserHandler) Route() string { return "/users" }
+
+type OrderHandler struct {
+	DB *DB
+}
+
+func (h *OrderHandler) Route() string { return "/orders" }
+
+type Metrics interface {
+	Count(name string)
+}
+
+type Cache struct{}
+
+type Server struct {
+	Handlers []Handler
+	Replica  *DB
+	Metrics  container.Optional[Metrics]
+	Cache    *Cache
+}
+
+var ErrNoDSN = errors.New("no dsn")
+
+func NewConfig() *Config {
+	return &Config{DSN: "primary"}
+}
+
+func NewDB(config *Config) (*DB, error) {
+	if config.DSN == "" {
+		return nil, ErrNoDSN
+	}
+	return &DB{Config: config}, nil
+}
+
+func NewReplica() *DB {
+	return &DB{Config: &Config{DSN: "replica"}}
+}
+
+func NewUserHandler(db *DB, config container.Optional[*Config]) *UserHandler {
+	return &UserHandler{DB: db, Config: config.Value}
+}
+
+func NewOrderHandler(db *DB) *OrderHandler {
+	return &OrderHandler{DB: db}
+}
+
+func NewServer(handlers []Handler, replica *DB, metrics container.Optional[Metrics], cache *Cache) *Server {
+	return &Server{Handlers: handlers, Replica: replica, Metrics: metrics, Cache: cache}
+}
+
+func Bindings(c *container.Container) error {
+	container.MustBindInstance[*Config](c, NewConfig)
+	if err := container.BindInstance[*DB](c, NewDB); err != nil {
+		return err
+	}
+	container.MustBindInstance[*DB](c, NewReplica, container.WithName("replica"))
+	container.MustBindInstance[Handler](c, NewOrderHandler, container.WithLifetime(container.Transient))
+	container.MustBindInstance[Handler](c, NewUserHandler)
+	container.MustBindInstance[*UserHandler](c, NewUserHandler)
+	container.MustBindInstance[Handler](c, NewOrderHandler, container.WithLifetime(container.Transient))
+	return container.BindInstance[*Server](c, NewServer, container.WithArgName(1, "replica"), container.WithOptionalArg(3))
+}
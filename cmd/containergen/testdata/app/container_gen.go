@@ -0,0 +1,346 @@
+// Code generated by containergen from Bindings. DO NOT EDIT.
+
+package app
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/gobros/container"
+)
+
+// Builds the bindings declared in Bindings without reflection. Safe for
+// concurrent use.
+type GeneratedContainer struct {
+	lock sync.Mutex
+	// Built by NewConfig
+	singleton0 *Config
+	built0     bool
+	// Built by NewDB
+	singleton1 *DB
+	built1     bool
+	// Built by NewReplica
+	singleton2 *DB
+	built2     bool
+	// Built by NewUserHandler
+	singleton3 *UserHandler
+	built3     bool
+	// Built by NewServer
+	singleton4 *Server
+	built4     bool
+}
+
+// Creates an empty GeneratedContainer. Nothing is built until it's resolved.
+func NewGeneratedContainer() *GeneratedContainer {
+	return &GeneratedContainer{}
+}
+
+// Resolves every concrete bound to *app.Config
+func (c *GeneratedContainer) ResolveAllConfig() ([]*Config, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.all0()
+}
+
+// Resolves the concrete most recently bound to *app.Config
+func (c *GeneratedContainer) ResolveConfig() (*Config, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	all, err := c.all0()
+	if err != nil {
+		var zero *Config
+		return zero, err
+	}
+	return all[len(all)-1], nil
+}
+
+// Resolves every concrete bound to *app.DB
+func (c *GeneratedContainer) ResolveAllDB() ([]*DB, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.all1()
+}
+
+// Resolves the concrete most recently bound to *app.DB
+func (c *GeneratedContainer) ResolveDB() (*DB, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	all, err := c.all1()
+	if err != nil {
+		var zero *DB
+		return zero, err
+	}
+	return all[len(all)-1], nil
+}
+
+// Resolves every concrete bound to *app.DB(name=replica)
+func (c *GeneratedContainer) ResolveAllDBReplica() ([]*DB, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.all2()
+}
+
+// Resolves the concrete most recently bound to *app.DB(name=replica)
+func (c *GeneratedContainer) ResolveDBReplica() (*DB, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	all, err := c.all2()
+	if err != nil {
+		var zero *DB
+		return zero, err
+	}
+	return all[len(all)-1], nil
+}
+
+// Resolves every concrete bound to app.Handler
+func (c *GeneratedContainer) ResolveAllHandler() ([]Handler, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.all3()
+}
+
+// Resolves the concrete most recently bound to app.Handler
+func (c *GeneratedContainer) ResolveHandler() (Handler, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	all, err := c.all3()
+	if err != nil {
+		var zero Handler
+		return zero, err
+	}
+	return all[len(all)-1], nil
+}
+
+// Resolves every concrete bound to *app.UserHandler
+func (c *GeneratedContainer) ResolveAllUserHandler() ([]*UserHandler, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.all4()
+}
+
+// Resolves the concrete most recently bound to *app.UserHandler
+func (c *GeneratedContainer) ResolveUserHandler() (*UserHandler, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	all, err := c.all4()
+	if err != nil {
+		var zero *UserHandler
+		return zero, err
+	}
+	return all[len(all)-1], nil
+}
+
+// Resolves every concrete bound to *app.Server
+func (c *GeneratedContainer) ResolveAllServer() ([]*Server, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.all5()
+}
+
+// Resolves the concrete most recently bound to *app.Server
+func (c *GeneratedContainer) ResolveServer() (*Server, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	all, err := c.all5()
+	if err != nil {
+		var zero *Server
+		return zero, err
+	}
+	return all[len(all)-1], nil
+}
+
+func (c *GeneratedContainer) all0() ([]*Config, error) {
+	all := make([]*Config, 0, 1)
+	instance0, err := c.build0()
+	if err != nil {
+		return nil, err
+	}
+	all = append(all, instance0)
+	return all, nil
+}
+
+func (c *GeneratedContainer) all1() ([]*DB, error) {
+	all := make([]*DB, 0, 1)
+	instance1, err := c.build1()
+	if err != nil {
+		return nil, err
+	}
+	all = append(all, instance1)
+	return all, nil
+}
+
+func (c *GeneratedContainer) all2() ([]*DB, error) {
+	all := make([]*DB, 0, 1)
+	instance2, err := c.build2()
+	if err != nil {
+		return nil, err
+	}
+	all = append(all, instance2)
+	return all, nil
+}
+
+func (c *GeneratedContainer) all3() ([]Handler, error) {
+	all := make([]Handler, 0, 2)
+	instance4, err := c.build4()
+	if err != nil {
+		return nil, err
+	}
+	all = append(all, instance4)
+	instance6, err := c.build6()
+	if err != nil {
+		return nil, err
+	}
+	all = append(all, instance6)
+	return all, nil
+}
+
+func (c *GeneratedContainer) all4() ([]*UserHandler, error) {
+	all := make([]*UserHandler, 0, 1)
+	instance5, err := c.build5()
+	if err != nil {
+		return nil, err
+	}
+	all = append(all, instance5)
+	return all, nil
+}
+
+func (c *GeneratedContainer) all5() ([]*Server, error) {
+	all := make([]*Server, 0, 1)
+	instance7, err := c.build7()
+	if err != nil {
+		return nil, err
+	}
+	all = append(all, instance7)
+	return all, nil
+}
+
+func (c *GeneratedContainer) build0() (*Config, error) {
+	if c.built0 {
+		return c.singleton0, nil
+	}
+
+	instance := NewConfig()
+	c.singleton0, c.built0 = instance, true
+	return instance, nil
+}
+
+func (c *GeneratedContainer) build1() (*DB, error) {
+	if c.built1 {
+		return c.singleton1, nil
+	}
+
+	arg0All, err := c.all0()
+	if err != nil {
+		var zero *DB
+		return zero, fmt.Errorf("resolver dependency error, failed to resolve dependency (%v) for interface (%v): %w", "*app.Config", "*app.DB", err)
+	}
+	arg0 := arg0All[len(arg0All)-1]
+	instance, resolverErr := NewDB(arg0)
+	if resolverErr != nil {
+		var zero *DB
+		return zero, fmt.Errorf("failed to resolve for interface (%v), resolver returned error: %w", "*app.DB", resolverErr)
+	}
+	c.singleton1, c.built1 = instance, true
+	return instance, nil
+}
+
+func (c *GeneratedContainer) build2() (*DB, error) {
+	if c.built2 {
+		return c.singleton2, nil
+	}
+
+	instance := NewReplica()
+	c.singleton2, c.built2 = instance, true
+	return instance, nil
+}
+
+func (c *GeneratedContainer) build4() (*UserHandler, error) {
+	if c.built3 {
+		return c.singleton3, nil
+	}
+
+	arg0All, err := c.all1()
+	if err != nil {
+		var zero *UserHandler
+		return zero, fmt.Errorf("resolver dependency error, failed to resolve dependency (%v) for interface (%v): %w", "*app.DB", "app.Handler", err)
+	}
+	arg0 := arg0All[len(arg0All)-1]
+	arg1All, err := c.all0()
+	if err != nil {
+		var zero *UserHandler
+		return zero, fmt.Errorf("resolver dependency error, failed to resolve dependency (%v) for interface (%v): %w", "*app.Config", "app.Handler", err)
+	}
+	arg1 := container.Optional[*Config]{Value: arg1All[len(arg1All)-1], Present: true}
+	instance := NewUserHandler(arg0, arg1)
+	c.singleton3, c.built3 = instance, true
+	return instance, nil
+}
+
+func (c *GeneratedContainer) build6() (*OrderHandler, error) {
+	arg0All, err := c.all1()
+	if err != nil {
+		var zero *OrderHandler
+		return zero, fmt.Errorf("resolver dependency error, failed to resolve dependency (%v) for interface (%v): %w", "*app.DB", "app.Handler", err)
+	}
+	arg0 := arg0All[len(arg0All)-1]
+	instance := NewOrderHandler(arg0)
+	return instance, nil
+}
+
+func (c *GeneratedContainer) build5() (*UserHandler, error) {
+	if c.built3 {
+		return c.singleton3, nil
+	}
+
+	arg0All, err := c.all1()
+	if err != nil {
+		var zero *UserHandler
+		return zero, fmt.Errorf("resolver dependency error, failed to resolve dependency (%v) for interface (%v): %w", "*app.DB", "*app.UserHandler", err)
+	}
+	arg0 := arg0All[len(arg0All)-1]
+	arg1All, err := c.all0()
+	if err != nil {
+		var zero *UserHandler
+		return zero, fmt.Errorf("resolver dependency error, failed to resolve dependency (%v) for interface (%v): %w", "*app.Config", "*app.UserHandler", err)
+	}
+	arg1 := container.Optional[*Config]{Value: arg1All[len(arg1All)-1], Present: true}
+	instance := NewUserHandler(arg0, arg1)
+	c.singleton3, c.built3 = instance, true
+	return instance, nil
+}
+
+func (c *GeneratedContainer) build7() (*Server, error) {
+	if c.built4 {
+		return c.singleton4, nil
+	}
+
+	arg0All, err := c.all3()
+	if err != nil {
+		var zero *Server
+		return zero, fmt.Errorf("resolver dependency error, failed to resolve dependency (%v) for interface (%v): %w", "[]app.Handler", "*app.Server", err)
+	}
+	arg0 := arg0All
+	arg1All, err := c.all2()
+	if err != nil {
+		var zero *Server
+		return zero, fmt.Errorf("resolver dependency error, failed to resolve dependency (%v) for interface (%v): %w", "*app.DB(name=replica)", "*app.Server", err)
+	}
+	arg1 := arg1All[len(arg1All)-1]
+	var arg2 container.Optional[Metrics]
+	var arg3 *Cache
+	instance := NewServer(arg0, arg1, arg2, arg3)
+	c.singleton4, c.built4 = instance, true
+	return instance, nil
+}
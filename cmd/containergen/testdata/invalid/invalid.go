@@ -0,0 +1,73 @@
+// Package invalid declares bindings containergen should refuse to generate
+package invalid
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gobros/container"
+)
+
+type A struct{}
+
+type B struct{}
+
+func NewA(b *B) *A { return &A{} }
+
+func NewB(a *A) *B { return &B{} }
+
+func NewLoneA() *A { return &A{} }
+
+func MissingDependency(c *container.Container) {
+	container.MustBindInstance[*A](c, NewA)
+}
+
+func Cycle(c *container.Container) {
+	container.MustBindInstance[*A](c, NewA)
+	container.MustBindInstance[*B](c, NewB)
+}
+
+func Scoped(c *container.Container) {
+	container.MustBindInstance[*A](c, NewLoneA, container.WithLifetime(container.Scoped))
+}
+
+func Results(c *container.Container) {
+	container.MustBindResultsInstance(c, NewLoneA)
+}
+
+func UnsupportedStatement(c *container.Container) {
+	container.MustBindInstance[*A](c, NewLoneA)
+	fmt.Println("bound")
+}
+
+func FuncLiteral(c *container.Container) {
+	container.MustBindInstance[*A](c, func() *A { return &A{} })
+}
+
+func NonConstantName(c *container.Container, name string) {
+	container.MustBindInstance[*A](c, NewLoneA, container.WithName(name))
+}
+
+func NewLazyA(b container.Lazy[*B]) *A { return &A{} }
+
+func LazyArgument(c *container.Container) {
+	container.MustBindInstance[*A](c, NewLazyA)
+}
+
+func NewContextA(ctx context.Context) *A { return &A{} }
+
+func ContextArgument(c *container.Container) {
+	container.MustBindInstance[*A](c, NewContextA)
+}
+
+type AParams struct {
+	container.In
+
+	B *B
+}
+
+func NewParamsA(params AParams) *A { return &A{} }
+
+func ParamStruct(c *container.Container) {
+	container.MustBindInstance[*A](c, NewParamsA)
+}
@@ -0,0 +1,106 @@
+// Code generated by containergen from Bindings. DO NOT EDIT.
+
+package simple
+
+import (
+	"sync"
+)
+
+// Builds the bindings declared in Bindings without reflection. Safe for
+// concurrent use.
+type GeneratedContainer struct {
+	lock sync.Mutex
+	// Built by NewDB
+	singleton0 *DB
+	built0     bool
+	// Built by NewCache
+	singleton1 *Cache
+	built1     bool
+}
+
+// Creates an empty GeneratedContainer. Nothing is built until it's resolved.
+func NewGeneratedContainer() *GeneratedContainer {
+	return &GeneratedContainer{}
+}
+
+// Resolves every concrete bound to *simple.DB
+func (c *GeneratedContainer) ResolveAllDB() ([]*DB, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.all0()
+}
+
+// Resolves the concrete most recently bound to *simple.DB
+func (c *GeneratedContainer) ResolveDB() (*DB, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	all, err := c.all0()
+	if err != nil {
+		var zero *DB
+		return zero, err
+	}
+	return all[len(all)-1], nil
+}
+
+// Resolves every concrete bound to *simple.Cache
+func (c *GeneratedContainer) ResolveAllCache() ([]*Cache, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.all1()
+}
+
+// Resolves the concrete most recently bound to *simple.Cache
+func (c *GeneratedContainer) ResolveCache() (*Cache, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	all, err := c.all1()
+	if err != nil {
+		var zero *Cache
+		return zero, err
+	}
+	return all[len(all)-1], nil
+}
+
+func (c *GeneratedContainer) all0() ([]*DB, error) {
+	all := make([]*DB, 0, 1)
+	instance0, err := c.build0()
+	if err != nil {
+		return nil, err
+	}
+	all = append(all, instance0)
+	return all, nil
+}
+
+func (c *GeneratedContainer) all1() ([]*Cache, error) {
+	all := make([]*Cache, 0, 1)
+	instance1, err := c.build1()
+	if err != nil {
+		return nil, err
+	}
+	all = append(all, instance1)
+	return all, nil
+}
+
+func (c *GeneratedContainer) build0() (*DB, error) {
+	if c.built0 {
+		return c.singleton0, nil
+	}
+
+	instance := NewDB()
+	c.singleton0, c.built0 = instance, true
+	return instance, nil
+}
+
+func (c *GeneratedContainer) build1() (*Cache, error) {
+	if c.built1 {
+		return c.singleton1, nil
+	}
+
+	instance := NewCache()
+	c.singleton1, c.built1 = instance, true
+	return instance, nil
+}
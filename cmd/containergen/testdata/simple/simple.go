@@ -0,0 +1,28 @@
+// Package simple declares bindings without dependencies or errors for the
+// containergen tests
+package simple
+
+import "github.com/gobros/container"
+
+type DB struct{}
+
+type Cache struct{}
+
+func NewDB() *DB {
+	return &DB{}
+}
+
+func NewCache() *Cache {
+	return &Cache{}
+}
+
+func Bindings(c *container.Container) error {
+	if err := container.BindInstance[*DB](c, NewDB); err != nil {
+		return err
+	}
+	err := container.BindInstance[*Cache](c, NewCache)
+	if err != nil {
+		return err
+	}
+	return nil
+}
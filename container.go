@@ -1,62 +1,304 @@
 package container
 
 import (
+	"context"
 	"fmt"
 	"reflect"
+	"sync"
+	"sync/atomic"
 )
 
-var Global = &Container{
-	bindingToResolver:          make(map[reflect.Type][]reflect.Value),
-	resolverToConcreteInstance: make(map[reflect.Value]any),
-}
+var Global = &Container{}
 
+// Holds bindings and the singletons built from them. The zero value is an
+// empty container ready for use. Safe for concurrent use by multiple
+// goroutines.
 type Container struct {
-	// Binds a pointer/interface to a resolver function
-	bindingToResolver map[reflect.Type][]reflect.Value
-	// Binds a resolver function to an instantiated concrete instance
-	resolverToConcreteInstance map[reflect.Value]any
+	// Guards bindingToResolver and acyclic
+	lock sync.RWMutex
+	// Binds a pointer/interface and name to a resolver function
+	bindingToResolver map[bindingKey][]*binding
+	// Bindings already known to have no dependency cycles. Cleared on bind.
+	acyclic map[bindingKey]bool
+	// Singletons built by the bound resolvers
+	instanceCache
+	// Whether the singletons have been started with Start
+	lifecycle lifecycle
+	// The container lookups fall back to when nothing is bound here, nil if
+	// this isn't a child container
+	parent *Container
+	// Modules installed into the container keyed by name. Guarded by lock.
+	modules map[string]*Module
+	// Told about binds and resolves, nil if there's no observer
+	observer atomic.Pointer[Observer]
+}
+
+// A single resolver bound to a bound type, along with the settings it was
+// bound with
+type binding struct {
+	resolver reflect.Value
+	lifetime Lifetime
+	// The value bound with BindValue. When set, resolver just returns it.
+	value reflect.Value
+	// The struct type bound with BindStruct, nil otherwise
+	structType reflect.Type
+	// The resolver as it was bound if resolver was built around it, to fill
+	// its parameter structs or return a field of its results struct. Invalid
+	// otherwise.
+	original reflect.Value
+	// The binding of the resolver that returns the results struct this
+	// binding returns a field of, nil otherwise. The field is always taken
+	// from its concrete, whatever else is bound to the results struct type.
+	results *binding
+	// Whether resolver was built for this binding to fill original's parameter
+	// structs. Since it's built for every bind, original identifies the
+	// binding's concretes instead.
+	fillsParams bool
+	// The arguments of resolver and where they're passed to original, until
+	// the bind options have been expanded to match them. Nil if the resolver
+	// doesn't take parameter structs.
+	paramArgs []paramArg
+	// The name the binding was bound with, empty if unnamed
+	name string
+	// Names of the bindings to resolve each resolver argument from, keyed by
+	// argument index. Arguments that aren't present resolve unnamed bindings.
+	argNames map[int]string
+	// Resolver arguments that receive their zero value when nothing is bound,
+	// keyed by argument index
+	argOptional map[int]bool
+	// Called with the concrete when the container or scope that cached it is
+	// closed, in the order they were registered
+	cleanups []func(any) error
+	// The types the cleanup funcs accept, used to validate them against the
+	// resolver
+	cleanupTypes []reflect.Type
+	// The module that provided the binding, nil if it was bound directly
+	module *Module
+	// Whether only the resolvers of module can depend on the binding
+	private bool
+}
+
+// Validates the settings the binding was bound with
+func (b *binding) validate() error {
+	if !b.lifetime.valid() {
+		return fmt.Errorf("resolver error, unknown lifetime (%v)", b.lifetime)
+	}
+	if b.value.IsValid() && b.lifetime != Singleton {
+		return fmt.Errorf("resolver error, values can only be bound with the Singleton lifetime")
+	}
+	if len(b.cleanups) > 0 && b.value.IsValid() {
+		return fmt.Errorf("resolver error, cleanup funcs can't be used with values since they aren't built by the container")
+	}
+	if len(b.cleanups) > 0 && b.lifetime == Transient {
+		return fmt.Errorf("resolver error, cleanup funcs can't be used with the Transient lifetime since its concretes aren't kept")
+	}
+	if b.private && b.module == nil {
+		return fmt.Errorf("resolver error, only bindings provided by a module can be private")
+	}
+	for _, cleanupType := range b.cleanupTypes {
+		if !b.resolver.Type().Out(0).AssignableTo(cleanupType) {
+			return fmt.Errorf("resolver error, cleanup func for (%v) can't accept the resolver return type (%v)", cleanupType, b.resolver.Type().Out(0))
+		}
+	}
+	for idx := range b.argNames {
+		if idx < 0 || idx >= b.resolver.Type().NumIn() {
+			return fmt.Errorf("resolver error, named argument index (%v) is out of range for a resolver with (%v) arguments", idx, b.resolver.Type().NumIn())
+		}
+	}
+	for idx := range b.argOptional {
+		if idx < 0 || idx >= b.resolver.Type().NumIn() {
+			return fmt.Errorf("resolver error, optional argument index (%v) is out of range for a resolver with (%v) arguments", idx, b.resolver.Type().NumIn())
+		}
+	}
+	return nil
+}
+
+// Returns the binding a resolver argument is resolved from
+func (b *binding) argKey(idx int) bindingKey {
+	return bindingKey{bindingType: dependencyType(b.resolver.Type().In(idx)), name: b.argNames[idx]}
+}
+
+// Returns true if the resolver argument receives every concrete bound to its
+// element type
+func (b *binding) argIsAll(idx int) bool {
+	return deferredElem(b.resolver.Type().In(idx)).Kind() == reflect.Slice
 }
 
+// Returns true if the resolver argument is resolved when it's used rather than
+// when the resolver is called
+func (b *binding) argIsDeferred(idx int) bool {
+	return isDeferredType(b.resolver.Type().In(idx))
+}
+
+// Returns true if any of the resolver's arguments are deferred
+func (b *binding) hasDeferredArgs() bool {
+	for i := 0; i < b.resolver.Type().NumIn(); i++ {
+		if b.argIsDeferred(i) {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns true if the resolver argument receives the context the resolve was
+// started with rather than anything bound
+func (b *binding) argIsContext(idx int) bool {
+	return deferredElem(b.resolver.Type().In(idx)) == contextType
+}
+
+// Returns true if the resolver argument is left empty when nothing is bound,
+// either because it was marked optional or it's an Optional
+func (b *binding) argIsOptional(idx int) bool {
+	return b.argOptional[idx] || isOptionalType(b.resolver.Type().In(idx))
+}
+
+// Returns what was passed in to be bound, either the resolver or the value
+func (b *binding) source() reflect.Value {
+	if b.value.IsValid() {
+		return b.value
+	}
+	return b.boundResolver()
+}
+
+// Returns the resolver as it was bound, rather than one built around it
+func (b *binding) boundResolver() reflect.Value {
+	if b.original.IsValid() {
+		return b.original
+	}
+	return b.resolver
+}
+
+// Returns true if both bindings were bound from the same resolver or value
+func (b *binding) sameAs(other *binding) bool {
+	if b.value.IsValid() || other.value.IsValid() {
+		return b.value.IsValid() && other.value.IsValid() &&
+			b.value.Type() == other.value.Type() &&
+			b.value.Comparable() && b.value.Equal(other.value)
+	}
+	return b.identity() == other.identity()
+}
+
+// What a binding was bound from. Bindings with the same identity are treated
+// as the same binding and share their concretes.
+type bindingIdentity struct {
+	resolver reflect.Value
+	// Returns the field of the results struct resolver returns, invalid
+	// unless the binding is for one of its fields
+	field reflect.Value
+}
+
+// Returns the identity of the binding, so bindings of the same resolver are
+// treated as the same and share their concretes
+func (b *binding) identity() bindingIdentity {
+	switch {
+	case b.fillsParams:
+		return bindingIdentity{resolver: b.original}
+	case b.results != nil:
+		return bindingIdentity{resolver: b.original, field: b.resolver}
+	}
+	return bindingIdentity{resolver: b.resolver}
+}
+
+// Configures how a resolver is bound. Passed to any of the Bind functions.
+type BindOption func(*binding)
+
 func EmptyContainer(container *Container) {
-	container.bindingToResolver = make(map[reflect.Type][]reflect.Value)
-	container.resolverToConcreteInstance = make(map[reflect.Value]any)
+	container.lock.Lock()
+	container.bindingToResolver = make(map[bindingKey][]*binding)
+	container.acyclic = nil
+	container.modules = nil
+	container.lock.Unlock()
+
+	container.instanceCache.reset()
 }
 
 // Binds a resolver to a bound type. Can later be resolved for use. Uses the
 // global container instance.
-func Bind[T any](resolver any) error {
-	return BindInstance[T](Global, resolver)
+func Bind[T any](resolver any, opts ...BindOption) error {
+	return BindInstance[T](Global, resolver, opts...)
 }
 
 // Binds a resolver to a bound type. Can later be resolved for use. Uses the
 // provided container instance.
-func BindInstance[T any](container *Container, resolver any) error {
-	resolveReturnType := getBindingType[T]()
+func BindInstance[T any](container *Container, resolver any, opts ...BindOption) error {
+	bindingType := getBindingType[T]()
+
+	newBinding, err := newResolverBinding(bindingType, resolver)
+	if err != nil {
+		return err
+	}
+
+	return addBinding(container, bindingType, newBinding, opts)
+}
+
+// Creates a binding for a resolver, ensuring the resolver is valid and has a
+// chance of functioning
+func newResolverBinding(bindingType reflect.Type, resolver any) (*binding, error) {
 	resolverType := reflect.ValueOf(resolver)
 
-	// Ensure the resolver is valid and has a chance of functioning
-	err := validateResolver(resolverType, resolveReturnType)
+	err := validateResolver(resolverType, bindingType)
 	if err != nil {
-		return fmt.Errorf("resolver validation failed: %w", err)
+		return nil, &ValidationError{Type: bindingType, Resolver: resolverType, Err: err}
+	}
+
+	if hasParamStructs(resolverType.Type()) {
+		return newParamBinding(resolverType), nil
+	}
+	return &binding{resolver: resolverType, lifetime: Singleton}, nil
+}
+
+// Applies the bind options to a new binding, validates it, and adds it to the
+// container. Shared by all the Bind functions.
+func addBinding(container *Container, bindingType reflect.Type, newBinding *binding, opts []BindOption) error {
+	if err := prepareBinding(bindingType, newBinding, opts); err != nil {
+		return err
+	}
+
+	container.lock.Lock()
+	overridden := container.insertBinding(bindingType, newBinding)
+	container.lock.Unlock()
+
+	container.observeBind(bindingType, newBinding, overridden)
+
+	return nil
+}
+
+// Applies the bind options to a new binding and validates it
+func prepareBinding(bindingType reflect.Type, newBinding *binding, opts []BindOption) error {
+	for _, opt := range opts {
+		opt(newBinding)
+	}
+	if err := newBinding.expandParamArgs(); err != nil {
+		return &ValidationError{Type: bindingType, Resolver: newBinding.source(), Err: err}
+	}
+	if err := newBinding.validate(); err != nil {
+		return &ValidationError{Type: bindingType, Resolver: newBinding.source(), Err: err}
+	}
+	return nil
+}
+
+// Adds a prepared binding to the container. Returns true if it replaced a
+// binding from the same resolver or value. Expects the container lock to be
+// held.
+func (container *Container) insertBinding(bindingType reflect.Type, newBinding *binding) bool {
+	if container.bindingToResolver == nil {
+		container.bindingToResolver = make(map[bindingKey][]*binding)
 	}
 
 	// If the concrete type is already bound, drop it so we can re-add it to the
 	// end, making it take precedence in a Resolve() call.
-	hasResolver, resolverIdx := findBoundResolver(container, resolverType, resolveReturnType)
+	key := bindingKey{bindingType: bindingType, name: newBinding.name}
+	hasResolver, resolverIdx := findBoundResolver(container, newBinding, key)
 	if hasResolver {
-		container.bindingToResolver[resolveReturnType] =
-			append(container.bindingToResolver[resolveReturnType][:resolverIdx],
-				container.bindingToResolver[resolveReturnType][resolverIdx+1:]...)
+		container.bindingToResolver[key] =
+			append(container.bindingToResolver[key][:resolverIdx],
+				container.bindingToResolver[key][resolverIdx+1:]...)
 	}
 
-	container.bindingToResolver[resolveReturnType] = append(container.bindingToResolver[resolveReturnType], resolverType)
+	container.bindingToResolver[key] = append(container.bindingToResolver[key], newBinding)
+	container.acyclic = nil
 
-	// Create an entry in the resolver to concrete instance map so we can reference it safely later
-	if _, ok := container.resolverToConcreteInstance[resolverType]; !ok {
-		container.resolverToConcreteInstance[resolverType] = nil
-	}
-
-	return nil
+	return hasResolver
 }
 
 // Attempts to resolve and return all concretes bound to the provided type as
@@ -68,14 +310,20 @@ func ResolveAll[T any]() ([]T, error) {
 // Attempts to resolve and return all concretes bound to the provided type as
 // a slice. Uses the provided container instance.
 func ResolveAllInstance[T any](container *Container) ([]T, error) {
+	return resolveAllTyped[T](container, resolution{}, "")
+}
+
+// Shared logic for the typed ResolveAll functions
+func resolveAllTyped[T any](container *Container, res resolution, name string) ([]T, error) {
 	resolverReturnType := getBindingType[T]()
+	key := bindingKey{bindingType: resolverReturnType, name: name}
 
-	resolvedInstance, err := resolveAllInstanceInternal(resolverReturnType, container)
+	resolvedInstance, err := resolveAllInstanceInternal(key, container, res)
 	if err != nil {
 		return nil, err
 	}
 	if arrRetVal, ok := resolvedInstance.([]T); !ok || len(arrRetVal) == 0 {
-		return nil, fmt.Errorf("failed to resolve for interface (%v), nothing bound", resolverReturnType.Name())
+		return nil, &NotBoundError{Type: resolverReturnType, Name: name, Path: []reflect.Type{resolverReturnType}}
 	}
 
 	return resolvedInstance.([]T), nil
@@ -100,86 +348,287 @@ func ResolveInstance[T any](container *Container) (T, error) {
 	return resolvedInstances[len(resolvedInstances)-1], nil
 }
 
+// Everything a chain of resolves needs to know about where it came from.
+// Passed by value so each resolver in the chain gets its own copy.
+type resolution struct {
+	// The context the resolve was started with, nil if it wasn't given one
+	ctx context.Context
+	// The scope scoped bindings resolve against, nil outside of a scope
+	scope *Scope
+	// Bindings currently being resolved, outermost first
+	path []bindingKey
+	// The module of the binding whose dependencies are being resolved, nil
+	// outside of any module
+	module *Module
+	// The ID of the resolve whose dependencies are being resolved, 0 at the
+	// top level or if there's no observer
+	resolveID uint64
+	// Index into path of the first binding resolved in the current container.
+	// Bindings before it were resolved in a child container and can't be part
+	// of a cycle, since parents never depend on their children.
+	cycleStart int
+	// Set while the resolver whose dependencies are being resolved is running,
+	// nil if it has no deferred arguments. Deferred arguments used before it
+	// returns continue this resolve rather than starting a new one.
+	constructing *atomic.Bool
+}
+
+// Returns the context the resolve was started with, or the background context
+// if it wasn't given one
+func (res resolution) context() context.Context {
+	if res.ctx == nil {
+		return context.Background()
+	}
+	return res.ctx
+}
+
+// Returns a copy of the resolution with the binding added to the end of the
+// path
+func (res resolution) enter(key bindingKey) resolution {
+	path := make([]bindingKey, len(res.path), len(res.path)+1)
+	copy(path, res.path)
+	res.path = append(path, key)
+	return res
+}
+
 // Shared logic for resolving all concrete instances for the given bound type
-func resolveAllInstanceInternal(bindingType reflect.Type, container *Container) (resolvedRet any, errRet error) {
+// and name
+func resolveAllInstanceInternal(key bindingKey, container *Container, res resolution) (any, error) {
+	// Bindings found in a parent are resolved in the parent, and can't depend
+	// on anything bound in this container
+	owner, resolvers := container.lookup(key)
+	if owner != container {
+		res.cycleStart = len(res.path)
+	}
+	resolvers, _ = visibleBindings(resolvers, res.module)
+
+	return resolveBindings(key, owner, resolvers, res)
+}
+
+// Resolves every one of the bindings, which must belong to the container, and
+// returns their concretes as a slice of the bound type
+func resolveBindings(key bindingKey, container *Container, resolvers []*binding, res resolution) (any, error) {
+	bindingType := key.bindingType
 	resolvedInstances := reflect.MakeSlice(reflect.SliceOf(bindingType), 0, 0)
-	resolvers := container.bindingToResolver[bindingType]
-	var resolverReturnType reflect.Type
 
-	// Rare case where it's much better to handle the panic and give a descriptive error
-	defer func() {
-		if r := recover(); r != nil {
-			returnType := resolverReturnType
-			resolvedRet = nil
-			errRet = fmt.Errorf("failed to resolve for interface (%v) for resolver with return type (%v), encountered panic (%v) ", bindingType.Name(), returnType, r)
+	// Resolving a type we're already in the middle of resolving would recurse forever
+	for idx := res.cycleStart; idx < len(res.path); idx++ {
+		if res.path[idx] == key {
+			cycle := append(append([]bindingKey(nil), res.path[idx:]...), key)
+			return nil, fmt.Errorf("failed to resolve for interface (%v), %w", key, newCycleError(cycle))
 		}
-	}()
+	}
+	res = res.enter(key)
 
 	// Call resolvers to get a concrete instance if we don't already have one
-	for _, resolver := range resolvers {
-		resolverReturnType = resolver.Type().Out(0)
+	for _, bound := range resolvers {
+		instance, err := resolveBinding(key, bound, container, res)
+		if err != nil {
+			return nil, err
+		}
 
-		if container.resolverToConcreteInstance[resolver] != nil {
-			break
+		// Add the resolved instance to the slice for return
+		instanceValue := reflect.ValueOf(instance)
+		if !instanceValue.IsValid() {
+			instanceValue = reflect.Zero(bindingType)
 		}
+		resolvedInstances = reflect.Append(resolvedInstances, instanceValue)
+	}
 
-		args, err := resolveArguments(container, resolver, bindingType)
-		if err != nil {
-			return nil, err
+	return resolvedInstances.Interface(), nil
+}
+
+// Resolves the concrete for a single binding, respecting its lifetime.
+// Singletons are cached in the container, scoped bindings are cached in the
+// scope, and transients are rebuilt on every resolve.
+func resolveBinding(key bindingKey, bound *binding, container *Container, res resolution) (any, error) {
+	if observer := container.getObserver(); observer != nil {
+		return observeResolve(observer, key, bound, container, res)
+	}
+	return resolveLifetime(key, bound, container, res, nil)
+}
+
+// Same as resolveBinding. Sets constructed to true if the resolver was called,
+// rather than the concrete coming from a cache, if constructed isn't nil.
+func resolveLifetime(key bindingKey, bound *binding, container *Container, res resolution, constructed *bool) (any, error) {
+	if bound.value.IsValid() {
+		return bound.value.Interface(), nil
+	}
+
+	markConstructed := func() {
+		if constructed != nil {
+			*constructed = true
 		}
+	}
+
+	switch bound.lifetime {
+	case Singleton:
+		// Singletons outlive any scope, so they must never see scoped concretes
+		res.scope = nil
+		return container.getOrBuild(bound, func() (any, error) {
+			markConstructed()
+			return buildCached(key, bound, container, res)
+		})
+	case Scoped:
+		scope := res.scope
+		if scope == nil {
+			return nil, fmt.Errorf("failed to resolve for interface (%v), %w", key, ErrNoScope)
+		}
+		return scope.getOrBuild(bound, func() (any, error) {
+			if scope.isClosed() {
+				return nil, fmt.Errorf("failed to resolve for interface (%v), %w", key, ErrScopeClosed)
+			}
+			markConstructed()
+			return buildCached(key, bound, container, res)
+		})
+	default:
+		markConstructed()
+		return callResolver(key, bound, container, res)
+	}
+}
+
+// Calls a resolver whose concrete will be cached. Other goroutines may end up
+// waiting on the result, so the dependency graph is checked for cycles first.
+// Otherwise two goroutines entering the same cycle from different ends would
+// wait on each other forever instead of failing.
+func buildCached(key bindingKey, bound *binding, container *Container, res resolution) (any, error) {
+	if cycle := container.findCycle(bound, key); cycle != nil {
+		return nil, fmt.Errorf("failed to resolve for interface (%v), %w", key, newCycleError(cycle))
+	}
+
+	return callResolver(key, bound, container, res)
+}
 
-		values := resolver.Call(args)
+// Resolves a resolver's arguments and calls it, returning the concrete it built
+func callResolver(key bindingKey, bound *binding, container *Container, res resolution) (resolvedRet any, errRet error) {
+	resolver := bound.resolver
+	boundResolver := bound.boundResolver()
 
-		// If we have 2 or more returns, the second return may be in an error state
-		if len(values) >= 2 && values[1].Interface() != nil {
-			return nil, fmt.Errorf("failed to resolve for interface (%v), resolver returned error: %w", bindingType.Name(), values[1].Interface().(error))
+	// Rare case where it's much better to handle the panic and give a descriptive error
+	defer func() {
+		if r := recover(); r != nil {
+			panicErr, _ := r.(error)
+			resolvedRet = nil
+			errRet = &ResolverError{Type: key.bindingType, Name: key.name, Resolver: boundResolver, Path: pathTypes(res.path), Err: panicErr, Panic: r}
 		}
+	}()
 
-		container.resolverToConcreteInstance[resolver] = values[0].Interface()
+	if bound.hasDeferredArgs() {
+		res.constructing = new(atomic.Bool)
+		res.constructing.Store(true)
+		defer res.constructing.Store(false)
 	}
 
-	// Add all the resolved instances to the slice for return
-	for _, resolver := range resolvers {
-		resolvedInstances = reflect.Append(resolvedInstances, reflect.ValueOf(container.resolverToConcreteInstance[resolver]))
+	args, err := resolveArguments(container, res, bound, key)
+	if err != nil {
+		return nil, err
 	}
 
-	return resolvedInstances.Interface(), nil
+	// Checked after the arguments are resolved so a chain of resolvers stops at
+	// the first one called after the context is done
+	if err := res.context().Err(); err != nil {
+		return nil, &CanceledError{Type: key.bindingType, Name: key.name, Path: pathTypes(res.path), Err: err}
+	}
+
+	values := resolver.Call(args)
+
+	// If we have 2 or more returns, the second return may be in an error state
+	if len(values) >= 2 && values[1].Interface() != nil {
+		return nil, &ResolverError{Type: key.bindingType, Name: key.name, Resolver: boundResolver, Path: pathTypes(res.path), Err: values[1].Interface().(error)}
+	}
+
+	return values[0].Interface(), nil
 }
 
 // Attempts to resolve all concrete instances for a resolver function's
 // arguments so the resolver can be called
-func resolveArguments(container *Container, resolverValue reflect.Value, bindingType reflect.Type) ([]reflect.Value, error) {
-	resolverType := resolverValue.Type()
+func resolveArguments(container *Container, res resolution, bound *binding, key bindingKey) ([]reflect.Value, error) {
+	resolverType := bound.resolver.Type()
 	argCount := resolverType.NumIn()
 	resolvedArgs := make([]reflect.Value, argCount)
 
+	// Private bindings are only visible to resolvers from the same module
+	res.module = bound.module
+
+	// A field of a results struct is taken from the binding that returned the
+	// struct, rather than whatever was most recently bound to its type
+	if bound.results != nil {
+		resultsKey := bound.argKey(0)
+		instance, err := resolveBinding(resultsKey, bound.results, container, res.enter(resultsKey))
+		if err != nil {
+			return nil, fmt.Errorf("resolver dependency error, failed to resolve dependency (%v) for interface (%v): %w", resultsKey, key, err)
+		}
+		return []reflect.Value{reflect.ValueOf(instance)}, nil
+	}
+
 	for i := 0; i < argCount; i++ {
-		argType := resolverType.In(i)
-		if argType.Kind() == reflect.Slice {
-			sliceType := argType.Elem()
-			arg, err := resolveAllInstanceInternal(sliceType, container)
-			if err != nil {
-				return resolvedArgs, fmt.Errorf("resolver dependency error, failed to resolve dependency (%v) for interface (%v): %w", argType, bindingType.Name(), err)
-			}
-			argVal := reflect.ValueOf(arg)
-			resolvedArgs[i] = argVal
-		} else {
-			arg, err := resolveAllInstanceInternal(argType, container)
-			if err != nil {
-				return nil, fmt.Errorf("resolver dependency error, failed to resolve dependency (%v) for interface (%v): %w", argType, bindingType.Name(), err)
-			}
+		argVal, err := resolveDependency(container, res, resolverType.In(i), bound.argKey(i), bound.argIsOptional(i), key)
+		if err != nil {
+			return nil, err
+		}
+		resolvedArgs[i] = argVal
+	}
 
-			argVal := reflect.ValueOf(arg)
+	return resolvedArgs, nil
+}
 
-			if argVal.Len() == 0 {
-				return nil, fmt.Errorf("resolver dependency error, failed to resolve dependency (%v) for interface (%v)", argType, bindingType.Name())
+// Resolves a single dependency of the binding with the provided key. Slices
+// receive every concrete bound to their element type. A context.Context
+// receives the context the resolve was started with. Anything else receives
+// the most recently bound concrete, or the zero value if the dependency is
+// optional and nothing is bound. Optional dependencies receive the concrete
+// wrapped in an Optional.
+func resolveDependency(container *Container, res resolution, argType reflect.Type, argKey bindingKey, optional bool, key bindingKey) (reflect.Value, error) {
+	if isDeferredType(argType) {
+		elemType := deferredElem(argType)
+		return newDeferred(argType, func() (reflect.Value, error) {
+			deferredRes := res
+			if res.constructing == nil || !res.constructing.Load() {
+				// Used after the resolver returned, so nothing is in the
+				// middle of being resolved, and the context it was given may
+				// already be done
+				deferredRes = resolution{scope: res.scope, module: res.module}
+			} else if cycle := container.findDeferredCycle(argKey, res.path[res.cycleStart:], res.module); cycle != nil {
+				// Another goroutine could be building the dependency while
+				// waiting on the resolver that's using it, so fail before
+				// waiting on it
+				return reflect.Value{}, fmt.Errorf("resolver dependency error, failed to resolve dependency (%v) for interface (%v): %w", argKey, key, newCycleError(cycle))
 			}
+			return resolveDependency(container, deferredRes, elemType, argKey, optional, key)
+		}), nil
+	}
 
-			resolvedArgs[i] = argVal.Index(argVal.Len() - 1)
+	if argType == contextType {
+		return reflect.ValueOf(res.context()), nil
+	}
+
+	if argType.Kind() == reflect.Slice {
+		arg, err := resolveAllInstanceInternal(argKey, container, res)
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("resolver dependency error, failed to resolve dependency (%v) for interface (%v): %w", argType, key, err)
 		}
+		return reflect.ValueOf(arg), nil
 	}
 
-	return resolvedArgs, nil
+	arg, err := resolveAllInstanceInternal(argKey, container, res)
+	if err != nil {
+		return reflect.Value{}, fmt.Errorf("resolver dependency error, failed to resolve dependency (%v) for interface (%v): %w", argKey, key, err)
+	}
+
+	argVal := reflect.ValueOf(arg)
+
+	if argVal.Len() == 0 {
+		if optional || isOptionalType(argType) {
+			return reflect.Zero(argType), nil
+		}
+		notBound := &NotBoundError{Type: argType, Name: argKey.name, Path: pathTypes(res.enter(argKey).path)}
+		return reflect.Value{}, fmt.Errorf("resolver dependency error, failed to resolve dependency (%v) for interface (%v): %w", argKey, key, notBound)
+	}
+
+	if isOptionalType(argType) {
+		return newOptional(argType, argVal.Index(argVal.Len()-1)), nil
+	}
+	return argVal.Index(argVal.Len() - 1), nil
 }
 
 // Returns the type of the generic interface T
@@ -216,23 +665,40 @@ func validateResolver(resolverType reflect.Value, genericType reflect.Type) erro
 
 	for i := 0; i < resolverType.Type().NumIn(); i++ {
 		paramType := resolverType.Type().In(i)
-
-		if paramType.Kind() != reflect.Ptr &&
-			paramType.Kind() != reflect.Interface &&
-			paramType.Kind() != reflect.Slice {
-			return fmt.Errorf("resolver error, resolver input parameters must all be of type pointer, interface, or slice")
+		if !isResolvableParam(paramType) && !isParamStruct(paramType) {
+			return fmt.Errorf("resolver error, resolver input parameters must all be of type pointer, interface, slice, Optional, Lazy, func() (T, error), or a parameter struct")
 		}
 	}
+	if err := validateParamStructs(resolverType.Type()); err != nil {
+		return fmt.Errorf("resolver error, %w", err)
+	}
 
 	return nil
 }
 
-// Searches for a bound resolver that returns the specified type. If found,
-// returns true and the index. Otherwise, returns false and -1.
-func findBoundResolver(container *Container, resolverType reflect.Value, resolverReturnType reflect.Type) (found bool, idx int) {
+// Returns true if a function parameter of the type can be resolved from the
+// container
+func isResolvableParam(paramType reflect.Type) bool {
+	if isDeferredType(paramType) {
+		elem := deferredElem(paramType)
+		return !isDeferredType(elem) && isResolvableParam(elem)
+	}
+	if isOptionalType(paramType) {
+		elem := optionalElem(paramType)
+		return elem.Kind() == reflect.Ptr || elem.Kind() == reflect.Interface
+	}
+	return paramType.Kind() == reflect.Ptr ||
+		paramType.Kind() == reflect.Interface ||
+		paramType.Kind() == reflect.Slice
+}
+
+// Searches for an existing binding to the specified key that was bound from
+// the same resolver or value. If found, returns true and the index. Otherwise,
+// returns false and -1.
+func findBoundResolver(container *Container, newBinding *binding, key bindingKey) (found bool, idx int) {
 	var foundIdx = -1
-	for idx, existingResolverType := range container.bindingToResolver[resolverReturnType] {
-		if existingResolverType == resolverType {
+	for idx, existingBinding := range container.bindingToResolver[key] {
+		if existingBinding.sameAs(newBinding) {
 			foundIdx = idx
 			return true, foundIdx
 		}
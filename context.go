@@ -0,0 +1,71 @@
+package container
+
+import (
+	"context"
+	"reflect"
+)
+
+// The type of resolver arguments that receive the context the resolve was
+// started with
+var contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+
+// Attempts to resolve and return all concretes bound to the provided type as
+// a slice. Resolvers with a context.Context argument receive ctx, and the
+// resolve stops with a CanceledError if ctx is done before a resolver is
+// called. Uses the global container instance.
+func ResolveAllContext[T any](ctx context.Context) ([]T, error) {
+	return ResolveAllInstanceContext[T](ctx, Global)
+}
+
+// Attempts to resolve and return all concretes bound to the provided type as
+// a slice. Resolvers with a context.Context argument receive ctx, and the
+// resolve stops with a CanceledError if ctx is done before a resolver is
+// called. Uses the provided container instance.
+func ResolveAllInstanceContext[T any](ctx context.Context, container *Container) ([]T, error) {
+	return resolveAllTyped[T](container, resolution{ctx: ctx}, "")
+}
+
+// Attempts to resolve and return all concretes bound to the provided type as
+// a slice. Resolvers with a context.Context argument receive ctx, and the
+// resolve stops with a CanceledError if ctx is done before a resolver is
+// called. Scoped bindings are resolved against the provided scope.
+func ResolveAllScopeContext[T any](ctx context.Context, scope *Scope) ([]T, error) {
+	return resolveAllTyped[T](scope.container, resolution{ctx: ctx, scope: scope}, "")
+}
+
+// Resolves a single concrete bound to the provided type. If multiple resolvers
+// were bound, the concrete from the most recent one is returned. Resolvers
+// with a context.Context argument receive ctx, and the resolve stops with a
+// CanceledError if ctx is done before a resolver is called. Uses the global
+// container instance.
+func ResolveContext[T any](ctx context.Context) (T, error) {
+	return ResolveInstanceContext[T](ctx, Global)
+}
+
+// Resolves a single concrete bound to the provided type. If multiple resolvers
+// were bound, the concrete from the most recent one is returned. Resolvers
+// with a context.Context argument receive ctx, and the resolve stops with a
+// CanceledError if ctx is done before a resolver is called. Uses the provided
+// container instance.
+func ResolveInstanceContext[T any](ctx context.Context, container *Container) (T, error) {
+	resolvedInstances, err := ResolveAllInstanceContext[T](ctx, container)
+	if err != nil {
+		return *new(T), err
+	}
+
+	return resolvedInstances[len(resolvedInstances)-1], nil
+}
+
+// Resolves a single concrete bound to the provided type. If multiple resolvers
+// were bound, the concrete from the most recent one is returned. Resolvers
+// with a context.Context argument receive ctx, and the resolve stops with a
+// CanceledError if ctx is done before a resolver is called. Scoped bindings
+// are resolved against the provided scope.
+func ResolveScopeContext[T any](ctx context.Context, scope *Scope) (T, error) {
+	resolvedInstances, err := ResolveAllScopeContext[T](ctx, scope)
+	if err != nil {
+		return *new(T), err
+	}
+
+	return resolvedInstances[len(resolvedInstances)-1], nil
+}
@@ -0,0 +1,238 @@
+package container_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/gobros/container"
+	"github.com/stretchr/testify/assert"
+)
+
+type contextKey struct{}
+
+func TestResolveContextPassesContext(t *testing.T) {
+	// Given
+	setup()
+
+	var received context.Context
+	container.MustBind[PrimaryIDGiver](func(ctx context.Context) *TestStruct1 {
+		received = ctx
+		return NewTestStruct1()
+	})
+	ctx := context.WithValue(context.Background(), contextKey{}, "trace")
+
+	// When
+	_, err := container.ResolveContext[PrimaryIDGiver](ctx)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "trace", received.Value(contextKey{}))
+
+	cleanup()
+}
+
+func TestResolveContextPassesContextToDependencies(t *testing.T) {
+	// Given
+	setup()
+
+	var received context.Context
+	container.MustBind[PrimaryIDGiver](func(ctx context.Context) *TestStruct1 {
+		received = ctx
+		return NewTestStruct1()
+	}, container.WithLifetime(container.Transient))
+	container.MustBind[SecondaryIDGiver](NewTestStruct2)
+	container.MustBind[IDAggregator](NewTestIDAggregatorStruct, container.WithLifetime(container.Transient))
+	ctx := context.WithValue(context.Background(), contextKey{}, "trace")
+
+	// When
+	_, err := container.ResolveContext[IDAggregator](ctx)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "trace", received.Value(contextKey{}))
+
+	cleanup()
+}
+
+func TestResolveWithoutContextPassesBackground(t *testing.T) {
+	// Given
+	setup()
+
+	var received context.Context
+	container.MustBind[PrimaryIDGiver](func(ctx context.Context) *TestStruct1 {
+		received = ctx
+		return NewTestStruct1()
+	})
+
+	// When
+	_, err := container.Resolve[PrimaryIDGiver]()
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, context.Background(), received)
+
+	cleanup()
+}
+
+func TestResolveScopeContext(t *testing.T) {
+	// Given
+	setup()
+
+	var received context.Context
+	container.MustBind[PrimaryIDGiver](func(ctx context.Context) *TestStruct1 {
+		received = ctx
+		return NewTestStruct1()
+	}, container.WithLifetime(container.Scoped))
+	scope := container.Global.NewScope()
+	ctx := context.WithValue(context.Background(), contextKey{}, "request")
+
+	// When
+	resolved, err := container.ResolveScopeContext[PrimaryIDGiver](ctx, scope)
+	all, allErr := container.ResolveAllScopeContext[PrimaryIDGiver](context.Background(), scope)
+
+	// Then
+	assert.NoError(t, err)
+	assert.NoError(t, allErr)
+	assert.Equal(t, "request", received.Value(contextKey{}))
+	assert.Equal(t, []PrimaryIDGiver{resolved}, all)
+	assert.Equal(t, 1, Str1InstanceNumber)
+
+	assert.NoError(t, scope.Close())
+	cleanup()
+}
+
+func TestResolveContextCanceled(t *testing.T) {
+	// Given
+	setup()
+
+	container.MustBind[PrimaryIDGiver](NewTestStruct1)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// When
+	_, err := container.ResolveContext[PrimaryIDGiver](ctx)
+
+	// Then
+	var canceledErr *container.CanceledError
+	assert.ErrorAs(t, err, &canceledErr)
+	assert.ErrorIs(t, err, container.ErrResolveCanceled)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, reflect.TypeOf((*PrimaryIDGiver)(nil)).Elem(), canceledErr.Type)
+	assert.Equal(t, []reflect.Type{reflect.TypeOf((*PrimaryIDGiver)(nil)).Elem()}, canceledErr.Path)
+	assert.Equal(t, 0, Str1InstanceNumber)
+
+	cleanup()
+}
+
+func TestResolveContextCanceledBetweenResolvers(t *testing.T) {
+	// Given
+	setup()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	container.MustBind[PrimaryIDGiver](func() *TestStruct1 {
+		cancel()
+		return NewTestStruct1()
+	})
+	container.MustBind[SecondaryIDGiver](NewTestStruct2)
+	container.MustBind[IDAggregator](NewTestIDAggregatorStruct)
+
+	// When
+	_, err := container.ResolveContext[IDAggregator](ctx)
+
+	// Then
+	var canceledErr *container.CanceledError
+	assert.ErrorAs(t, err, &canceledErr)
+	assert.Equal(t, []reflect.Type{
+		reflect.TypeOf((*IDAggregator)(nil)).Elem(),
+		reflect.TypeOf((*SecondaryIDGiver)(nil)).Elem(),
+	}, canceledErr.Path)
+	assert.Equal(t, 1, Str1InstanceNumber)
+	assert.Equal(t, 0, Str2InstanceNumber)
+
+	// Errors aren't cached, so the singleton can still be resolved
+	_, err = container.Resolve[IDAggregator]()
+	assert.NoError(t, err)
+
+	cleanup()
+}
+
+func TestResolveContextDeadlineExceeded(t *testing.T) {
+	// Given
+	setup()
+
+	container.MustBind[PrimaryIDGiver](NewTestStruct1)
+	ctx, cancel := context.WithTimeout(context.Background(), 0)
+	defer cancel()
+
+	// When
+	_, err := container.ResolveAllContext[PrimaryIDGiver](ctx)
+
+	// Then
+	assert.ErrorIs(t, err, container.ErrResolveCanceled)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	cleanup()
+}
+
+func TestResolveContextCachedConcreteIgnoresCancel(t *testing.T) {
+	// Given
+	setup()
+
+	container.MustBind[PrimaryIDGiver](NewTestStruct1)
+	first := container.MustResolve[PrimaryIDGiver]()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// When
+	second, err := container.ResolveContext[PrimaryIDGiver](ctx)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Same(t, first, second)
+
+	cleanup()
+}
+
+func TestContextArgumentIsNotADependency(t *testing.T) {
+	// Given
+	setup()
+
+	container.MustBind[PrimaryIDGiver](func(ctx context.Context) *TestStruct1 {
+		return NewTestStruct1()
+	})
+
+	// When
+	validateErr := container.Global.Validate()
+	info := container.Global.Bindings()
+	graph := container.Global.Graph()
+
+	// Then
+	assert.NoError(t, validateErr)
+	assert.Len(t, info, 1)
+	assert.True(t, info[0].Resolvers[0].Dependencies[0].Context)
+	for _, edge := range graph.Edges {
+		assert.NotEqual(t, container.GraphEdgeDependency, edge.Kind)
+	}
+
+	cleanup()
+}
+
+func TestMustResolveContext(t *testing.T) {
+	// Given
+	setup()
+
+	container.MustBind[PrimaryIDGiver](NewTestStruct1, container.WithLifetime(container.Transient))
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// When
+	resolved := container.MustResolveContext[PrimaryIDGiver](ctx)
+	cancel()
+
+	// Then
+	assert.NotNil(t, resolved)
+	assert.Panics(t, func() { container.MustResolveContext[PrimaryIDGiver](ctx) })
+	assert.Panics(t, func() { container.MustResolveAllContext[PrimaryIDGiver](ctx) })
+
+	cleanup()
+}
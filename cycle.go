@@ -0,0 +1,178 @@
+package container
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Searches the bindings reachable from a resolver's arguments for a dependency
+// cycle without calling any resolvers. If one is found, returns the bindings
+// that make up the cycle with the first one repeated at the end. Otherwise,
+// returns nil.
+func (container *Container) findCycle(bound *binding, key bindingKey) []bindingKey {
+	container.lock.Lock()
+	defer container.lock.Unlock()
+
+	return container.findCycleFromResolver(bound, key)
+}
+
+// Same as findCycle, but expects the container lock to be held
+func (container *Container) findCycleFromResolver(bound *binding, key bindingKey) []bindingKey {
+	if container.acyclic == nil {
+		container.acyclic = make(map[bindingKey]bool)
+	}
+
+	path := []bindingKey{key}
+	for i := 0; i < bound.resolver.Type().NumIn(); i++ {
+		if bound.argIsDeferred(i) || bound.argIsContext(i) {
+			continue
+		}
+		if cycle := container.findCycleFrom(bound.argKey(i), path, bound.module); cycle != nil {
+			return cycle
+		}
+	}
+
+	return nil
+}
+
+// Searches the bindings reachable from a bound type for a dependency cycle.
+// Locks the container.
+func (container *Container) findCycleFromKey(key bindingKey, module *Module) []bindingKey {
+	container.lock.Lock()
+	defer container.lock.Unlock()
+
+	if container.acyclic == nil {
+		container.acyclic = make(map[bindingKey]bool)
+	}
+
+	return container.findCycleFrom(key, nil, module)
+}
+
+// Depth first search for findCycle, following the bindings visible to the
+// module. Expects the container lock to be held.
+func (container *Container) findCycleFrom(key bindingKey, path []bindingKey, module *Module) []bindingKey {
+	for idx, pathKey := range path {
+		if pathKey == key {
+			return append(append([]bindingKey(nil), path[idx:]...), key)
+		}
+	}
+	// Bindings in a parent never lead back here, so any cycle they're part of
+	// is entirely within the parent
+	if len(container.bindingToResolver[key]) == 0 && container.parent != nil {
+		return container.parent.findCycleFromKey(key, module)
+	}
+	if container.acyclic[key] {
+		return nil
+	}
+
+	path = append(path, key)
+	visible, hidden := visibleBindings(container.bindingToResolver[key], module)
+	for _, bound := range visible {
+		for i := 0; i < bound.resolver.Type().NumIn(); i++ {
+			// Deferred arguments aren't resolved while the resolver is being
+			// called, and contexts aren't resolved from bindings at all, so
+			// neither can cause a cycle
+			if bound.argIsDeferred(i) || bound.argIsContext(i) {
+				continue
+			}
+			if cycle := container.findCycleFrom(bound.argKey(i), path, bound.module); cycle != nil {
+				return cycle
+			}
+		}
+	}
+
+	// Nothing reachable from here loops back, so there's no need to walk it
+	// again. Private bindings that were skipped could, so only remember it if
+	// every binding was walked.
+	if !hidden {
+		container.acyclic[key] = true
+	}
+
+	return nil
+}
+
+// A binding visited by findDeferredCycle, along with the module it was
+// visible to
+type deferredVisit struct {
+	key    bindingKey
+	module *Module
+}
+
+// Searches the bindings reachable from a deferred argument that's being used
+// while its resolver is running for one that's still being resolved. Any
+// goroutine building the bindings in between would wait on that resolve
+// while it waits on them, so they'd wait forever instead of failing. Deferred
+// arguments are followed too, since their resolvers may use them while
+// running as well. If one is found, returns the bindings that make up the
+// cycle. Otherwise, returns nil.
+func (container *Container) findDeferredCycle(key bindingKey, path []bindingKey, module *Module) []bindingKey {
+	container.lock.Lock()
+	defer container.lock.Unlock()
+
+	path = append([]bindingKey(nil), path...)
+	return container.findDeferredCycleFrom(key, path, module, make(map[deferredVisit]bool))
+}
+
+// Depth first search for findDeferredCycle. Expects the container lock to be
+// held.
+func (container *Container) findDeferredCycleFrom(key bindingKey, path []bindingKey, module *Module, visited map[deferredVisit]bool) []bindingKey {
+	for idx, pathKey := range path {
+		if pathKey == key {
+			return append(append([]bindingKey(nil), path[idx:]...), key)
+		}
+	}
+	// Bindings only found in a parent can't lead back to the bindings being
+	// resolved here, and everything reachable from a binding was already
+	// searched the first time it was visited
+	visit := deferredVisit{key: key, module: module}
+	if len(container.bindingToResolver[key]) == 0 || visited[visit] {
+		return nil
+	}
+	visited[visit] = true
+
+	path = append(path, key)
+	visible, _ := visibleBindings(container.bindingToResolver[key], module)
+	for _, bound := range visible {
+		for i := 0; i < bound.resolver.Type().NumIn(); i++ {
+			if bound.argIsContext(i) {
+				continue
+			}
+			if cycle := container.findDeferredCycleFrom(bound.argKey(i), path, bound.module, visited); cycle != nil {
+				return cycle
+			}
+		}
+	}
+
+	return nil
+}
+
+// Returns the bound type a resolver argument is resolved from. Slice,
+// Optional, Lazy and func arguments are resolved from their element type.
+func dependencyType(argType reflect.Type) reflect.Type {
+	argType = deferredElem(argType)
+	if argType.Kind() == reflect.Slice {
+		return argType.Elem()
+	}
+	if isOptionalType(argType) {
+		return optionalElem(argType)
+	}
+	return argType
+}
+
+// Formats a dependency path for use in an error, e.g. "*Server -> Handler"
+func formatPath(path []bindingKey) string {
+	names := make([]string, len(path))
+	for idx, pathKey := range path {
+		names[idx] = pathKey.String()
+	}
+	return strings.Join(names, " -> ")
+}
+
+// Returns just the bound types from a dependency path
+func pathTypes(path []bindingKey) []reflect.Type {
+	types := make([]reflect.Type, len(path))
+	for idx, pathKey := range path {
+		types[idx] = pathKey.bindingType
+	}
+	return types
+}
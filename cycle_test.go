@@ -0,0 +1,170 @@
+package container_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/gobros/container"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCycleDetected(t *testing.T) {
+	// Given
+	setup()
+	bindCycle(t, container.Singleton)
+
+	// When
+	val, err := container.Resolve[*cycleServer]()
+
+	// Then
+	assert.Nil(t, val)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "*container_test.cycleServer -> container_test.cycleHandler -> *container_test.cycleRepo -> *container_test.cycleServer")
+
+	cleanup()
+}
+
+func TestCycleDetectedTransient(t *testing.T) {
+	// Given
+	setup()
+	bindCycle(t, container.Transient)
+
+	// When
+	val, err := container.Resolve[cycleHandler]()
+
+	// Then
+	assert.Nil(t, val)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "container_test.cycleHandler -> *container_test.cycleRepo -> *container_test.cycleServer -> container_test.cycleHandler")
+
+	cleanup()
+}
+
+func TestCycleDetectedSelf(t *testing.T) {
+	// Given
+	setup()
+	err := container.Bind[*cycleServer](func(s *cycleServer) *cycleServer {
+		return &cycleServer{}
+	})
+	assert.NoError(t, err)
+
+	// When
+	val, err := container.Resolve[*cycleServer]()
+
+	// Then
+	assert.Nil(t, val)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "*container_test.cycleServer -> *container_test.cycleServer")
+
+	cleanup()
+}
+
+func TestCycleDetectedThroughSlice(t *testing.T) {
+	// Given
+	setup()
+	err := container.Bind[*cycleServer](func(handlers []cycleHandler) *cycleServer {
+		return &cycleServer{}
+	})
+	assert.NoError(t, err)
+	err = container.Bind[cycleHandler](func(s *cycleServer) *cycleHandlerImpl {
+		return &cycleHandlerImpl{}
+	})
+	assert.NoError(t, err)
+
+	// When
+	val, err := container.Resolve[*cycleServer]()
+
+	// Then
+	assert.Nil(t, val)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "*container_test.cycleServer -> container_test.cycleHandler -> *container_test.cycleServer")
+
+	cleanup()
+}
+
+func TestNoCycleForSharedDependency(t *testing.T) {
+	// Given
+	setup()
+
+	// PrimaryIDGiver is reached twice, but never from itself
+	err := container.Bind[PrimaryIDGiver](NewTestStruct1)
+	assert.NoError(t, err)
+	err = container.Bind[SecondaryIDGiver](func(prim PrimaryIDGiver) *TestStruct2 {
+		return NewTestStruct2()
+	})
+	assert.NoError(t, err)
+	err = container.Bind[IDAggregator](NewTestIDAggregatorStruct)
+	assert.NoError(t, err)
+
+	// When
+	val, err := container.Resolve[IDAggregator]()
+
+	// Then
+	assert.NoError(t, err)
+	assert.NotNil(t, val)
+	assert.Equal(t, 1, Str1InstanceNumber)
+
+	cleanup()
+}
+
+func TestCycleConcurrentDoesntDeadlock(t *testing.T) {
+	// Given
+	c := &container.Container{}
+	assert.NoError(t, container.BindInstance[*cycleServer](c, func(h cycleHandler) *cycleServer { return &cycleServer{} }))
+	assert.NoError(t, container.BindInstance[cycleHandler](c, func(r *cycleRepo) *cycleHandlerImpl { return &cycleHandlerImpl{} }))
+	assert.NoError(t, container.BindInstance[*cycleRepo](c, func(s *cycleServer) *cycleRepo { return &cycleRepo{} }))
+
+	// When
+	errs := make([]error, 30)
+	var wg sync.WaitGroup
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			switch i % 3 {
+			case 0:
+				_, errs[i] = container.ResolveInstance[*cycleServer](c)
+			case 1:
+				_, errs[i] = container.ResolveInstance[cycleHandler](c)
+			default:
+				_, errs[i] = container.ResolveInstance[*cycleRepo](c)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	// Then
+	for _, err := range errs {
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "dependency cycle detected")
+	}
+}
+
+// Binds *cycleServer -> cycleHandler -> *cycleRepo -> *cycleServer
+func bindCycle(t *testing.T, lifetime container.Lifetime) {
+	err := container.Bind[*cycleServer](func(h cycleHandler) *cycleServer {
+		return &cycleServer{}
+	}, container.WithLifetime(lifetime))
+	assert.NoError(t, err)
+	err = container.Bind[cycleHandler](func(r *cycleRepo) *cycleHandlerImpl {
+		return &cycleHandlerImpl{}
+	}, container.WithLifetime(lifetime))
+	assert.NoError(t, err)
+	err = container.Bind[*cycleRepo](func(s *cycleServer) *cycleRepo {
+		return &cycleRepo{}
+	}, container.WithLifetime(lifetime))
+	assert.NoError(t, err)
+}
+
+// Test types that can be bound to form a cycle
+type cycleServer struct{}
+
+type cycleHandler interface {
+	Handle()
+}
+
+type cycleHandlerImpl struct{}
+
+func (c *cycleHandlerImpl) Handle() {}
+
+type cycleRepo struct{}
@@ -0,0 +1,162 @@
+package container
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+var (
+	// Nothing is bound to the bound type being resolved
+	ErrNotBound = errors.New("nothing bound")
+	// A resolver failed validation when it was bound
+	ErrInvalidResolver = errors.New("invalid resolver")
+	// A resolver panicked while it was being called
+	ErrResolverPanicked = errors.New("resolver panicked")
+	// Resolving a bound type requires resolving itself first
+	ErrDependencyCycle = errors.New("dependency cycle detected")
+	// A Scoped binding was resolved without a Scope
+	ErrNoScope = errors.New("scoped binding resolved outside of a scope")
+	// A Scope was used to resolve after it was closed
+	ErrScopeClosed = errors.New("scope is closed")
+	// A container was started while it was already started
+	ErrAlreadyStarted = errors.New("container already started")
+	// The context a resolve was started with was done before it finished
+	ErrResolveCanceled = errors.New("resolve canceled")
+)
+
+// Returned when nothing is bound to a bound type that's being resolved.
+// Matches ErrNotBound.
+type NotBoundError struct {
+	// The bound type that has nothing bound to it
+	Type reflect.Type
+	// The name that was asked for, empty if unnamed
+	Name string
+	// Bound types being resolved when the error occurred, ending with Type
+	Path []reflect.Type
+}
+
+func (e *NotBoundError) Error() string {
+	return fmt.Sprintf("failed to resolve for interface (%v), nothing bound", bindingKey{bindingType: e.Type, name: e.Name})
+}
+
+func (e *NotBoundError) Is(target error) bool {
+	return target == ErrNotBound
+}
+
+// Returned when a resolver returns an error or panics. Matches
+// ErrResolverPanicked if the resolver panicked.
+type ResolverError struct {
+	// The bound type being resolved
+	Type reflect.Type
+	// The name of the binding being resolved, empty if unnamed
+	Name string
+	// The resolver function that failed
+	Resolver reflect.Value
+	// Bound types being resolved when the error occurred, ending with Type
+	Path []reflect.Type
+	// The error returned by the resolver. If the resolver panicked with an
+	// error, this is the value it panicked with.
+	Err error
+	// The value the resolver panicked with, nil if it didn't panic
+	Panic any
+}
+
+func (e *ResolverError) Error() string {
+	key := bindingKey{bindingType: e.Type, name: e.Name}
+	if e.Panic != nil {
+		return fmt.Sprintf("failed to resolve for interface (%v) for resolver with return type (%v), encountered panic (%v)", key, e.Resolver.Type().Out(0), e.Panic)
+	}
+	return fmt.Sprintf("failed to resolve for interface (%v), resolver returned error: %v", key, e.Err)
+}
+
+func (e *ResolverError) Unwrap() error {
+	return e.Err
+}
+
+func (e *ResolverError) Is(target error) bool {
+	return target == ErrResolverPanicked && e.Panic != nil
+}
+
+// Returned when a resolver can't be bound to a bound type. Matches
+// ErrInvalidResolver.
+type ValidationError struct {
+	// The bound type the resolver was being bound to
+	Type reflect.Type
+	// The resolver that failed validation. Invalid if nil was bound.
+	Resolver reflect.Value
+	// Why the resolver failed validation
+	Err error
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("resolver validation failed: %v", e.Err)
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrInvalidResolver
+}
+
+// Returned when resolving a bound type requires resolving itself first.
+// Matches ErrDependencyCycle.
+type CycleError struct {
+	// The bound types that make up the cycle, starting and ending with the
+	// same type
+	Path []reflect.Type
+	// The names of the bindings in Path, empty for unnamed bindings
+	Names []string
+}
+
+// Creates a CycleError from the bindings that make up the cycle
+func newCycleError(cycle []bindingKey) *CycleError {
+	names := make([]string, len(cycle))
+	for idx, key := range cycle {
+		names[idx] = key.name
+	}
+	return &CycleError{Path: pathTypes(cycle), Names: names}
+}
+
+func (e *CycleError) Error() string {
+	cycle := make([]bindingKey, len(e.Path))
+	for idx, pathType := range e.Path {
+		cycle[idx] = bindingKey{bindingType: pathType}
+		if idx < len(e.Names) {
+			cycle[idx].name = e.Names[idx]
+		}
+	}
+	return fmt.Sprintf("%v (%v)", ErrDependencyCycle, formatPath(cycle))
+}
+
+func (e *CycleError) Is(target error) bool {
+	return target == ErrDependencyCycle
+}
+
+// Returned when the context a resolve was started with is done before a
+// resolver is called. Matches ErrResolveCanceled, and whatever the context's
+// error matches, such as context.Canceled or context.DeadlineExceeded.
+type CanceledError struct {
+	// The bound type whose resolver was about to be called
+	Type reflect.Type
+	// The name of the binding, empty if unnamed
+	Name string
+	// Bound types being resolved when the error occurred, ending with Type
+	Path []reflect.Type
+	// The context's error
+	Err error
+}
+
+func (e *CanceledError) Error() string {
+	return fmt.Sprintf("failed to resolve for interface (%v), %v: %v", bindingKey{bindingType: e.Type, name: e.Name}, ErrResolveCanceled, e.Err)
+}
+
+func (e *CanceledError) Unwrap() error {
+	return e.Err
+}
+
+func (e *CanceledError) Is(target error) bool {
+	return target == ErrResolveCanceled
+}
@@ -0,0 +1,199 @@
+package container_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/gobros/container"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorNothingBound(t *testing.T) {
+	// Given
+	setup()
+
+	// When
+	_, err := container.Resolve[PrimaryIDGiver]()
+
+	// Then
+	assert.ErrorIs(t, err, container.ErrNotBound)
+	var notBound *container.NotBoundError
+	assert.ErrorAs(t, err, &notBound)
+	assert.Equal(t, reflect.TypeOf((*PrimaryIDGiver)(nil)).Elem(), notBound.Type)
+
+	cleanup()
+}
+
+func TestErrorDependencyNotBound(t *testing.T) {
+	// Given
+	setup()
+
+	err := container.Bind[IDAggregator](NewTestIDAggregatorStruct)
+	assert.NoError(t, err)
+
+	// When
+	_, err = container.Resolve[IDAggregator]()
+
+	// Then
+	assert.ErrorIs(t, err, container.ErrNotBound)
+	var notBound *container.NotBoundError
+	assert.ErrorAs(t, err, &notBound)
+	assert.Equal(t, reflect.TypeOf((*SecondaryIDGiver)(nil)).Elem(), notBound.Type)
+	assert.Equal(t, []reflect.Type{
+		reflect.TypeOf((*IDAggregator)(nil)).Elem(),
+		reflect.TypeOf((*SecondaryIDGiver)(nil)).Elem(),
+	}, notBound.Path)
+
+	cleanup()
+}
+
+func TestErrorResolverReturnedError(t *testing.T) {
+	// Given
+	setup()
+
+	resolverErr := errors.New("resolver did a bad!")
+	resolver := func() (*TestStruct2, error) {
+		return nil, resolverErr
+	}
+	err := container.Bind[SecondaryIDGiver](resolver)
+	assert.NoError(t, err)
+	err = container.Bind[IDAggregator](NewTestIDAggregatorStruct)
+	assert.NoError(t, err)
+
+	// When
+	_, err = container.Resolve[IDAggregator]()
+
+	// Then
+	assert.ErrorIs(t, err, resolverErr)
+	assert.NotErrorIs(t, err, container.ErrResolverPanicked)
+	assert.NotErrorIs(t, err, container.ErrNotBound)
+	var resolverError *container.ResolverError
+	assert.ErrorAs(t, err, &resolverError)
+	assert.Equal(t, reflect.TypeOf((*SecondaryIDGiver)(nil)).Elem(), resolverError.Type)
+	assert.Equal(t, reflect.ValueOf(resolver).Pointer(), resolverError.Resolver.Pointer())
+	assert.Equal(t, []reflect.Type{
+		reflect.TypeOf((*IDAggregator)(nil)).Elem(),
+		reflect.TypeOf((*SecondaryIDGiver)(nil)).Elem(),
+	}, resolverError.Path)
+	assert.Nil(t, resolverError.Panic)
+
+	cleanup()
+}
+
+func TestErrorResolverPanicked(t *testing.T) {
+	// Given
+	setup()
+
+	err := container.Bind[PrimaryIDGiver](func() *TestStruct1 {
+		panic("resolver did a bad!")
+	})
+	assert.NoError(t, err)
+
+	// When
+	_, err = container.Resolve[PrimaryIDGiver]()
+
+	// Then
+	assert.ErrorIs(t, err, container.ErrResolverPanicked)
+	var resolverError *container.ResolverError
+	assert.ErrorAs(t, err, &resolverError)
+	assert.Equal(t, "resolver did a bad!", resolverError.Panic)
+	assert.Nil(t, resolverError.Err)
+
+	cleanup()
+}
+
+func TestErrorResolverPanickedWithError(t *testing.T) {
+	// Given
+	setup()
+
+	panicErr := errors.New("resolver did a bad!")
+	err := container.Bind[PrimaryIDGiver](func() *TestStruct1 {
+		panic(panicErr)
+	})
+	assert.NoError(t, err)
+
+	// When
+	_, err = container.Resolve[PrimaryIDGiver]()
+
+	// Then
+	assert.ErrorIs(t, err, container.ErrResolverPanicked)
+	assert.ErrorIs(t, err, panicErr)
+
+	cleanup()
+}
+
+func TestErrorValidation(t *testing.T) {
+	// Given
+	setup()
+
+	// When
+	err := container.Bind[PrimaryIDGiver](5)
+
+	// Then
+	assert.ErrorIs(t, err, container.ErrInvalidResolver)
+	var validationErr *container.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, reflect.TypeOf((*PrimaryIDGiver)(nil)).Elem(), validationErr.Type)
+	assert.Equal(t, 5, validationErr.Resolver.Interface())
+	assert.Contains(t, err.Error(), "resolver must be a function")
+
+	cleanup()
+}
+
+func TestErrorCycle(t *testing.T) {
+	// Given
+	setup()
+	bindCycle(t, container.Singleton)
+
+	// When
+	_, err := container.Resolve[*cycleServer]()
+
+	// Then
+	assert.ErrorIs(t, err, container.ErrDependencyCycle)
+	var cycleErr *container.CycleError
+	assert.ErrorAs(t, err, &cycleErr)
+	assert.Len(t, cycleErr.Path, 4)
+	assert.Equal(t, cycleErr.Path[0], cycleErr.Path[3])
+
+	cleanup()
+}
+
+func TestErrorScopes(t *testing.T) {
+	// Given
+	setup()
+
+	err := container.Bind[PrimaryIDGiver](NewTestStruct1, container.WithLifetime(container.Scoped))
+	assert.NoError(t, err)
+	scope := container.Global.NewScope()
+	assert.NoError(t, scope.Close())
+
+	// When
+	_, noScopeErr := container.Resolve[PrimaryIDGiver]()
+	_, closedErr := container.ResolveScope[PrimaryIDGiver](scope)
+
+	// Then
+	assert.ErrorIs(t, noScopeErr, container.ErrNoScope)
+	assert.ErrorIs(t, closedErr, container.ErrScopeClosed)
+
+	cleanup()
+}
+
+func TestMustPanicsWithError(t *testing.T) {
+	// Given
+	setup()
+
+	// When
+	recovered := func() (r any) {
+		defer func() { r = recover() }()
+		container.MustResolve[PrimaryIDGiver]()
+		return nil
+	}()
+
+	// Then
+	err, ok := recovered.(error)
+	assert.True(t, ok)
+	assert.ErrorIs(t, err, container.ErrNotBound)
+
+	cleanup()
+}
@@ -0,0 +1,381 @@
+package container
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"runtime"
+	"strings"
+)
+
+// The kinds of node in a Graph
+const (
+	// A bound type and name that can be resolved or depended on
+	GraphNodeType = "type"
+	// A resolver, value or struct bound to a bound type
+	GraphNodeResolver = "resolver"
+)
+
+// The kinds of edge in a Graph
+const (
+	// From a bound type to one of the resolvers bound to it
+	GraphEdgeBinding = "binding"
+	// From a resolver to the bound type one of its arguments is resolved from
+	GraphEdgeDependency = "dependency"
+	// From a resolver to the bound type a slice argument receives every
+	// concrete of
+	GraphEdgeAll = "all"
+)
+
+// A snapshot of how the bindings in a container connect, for display or
+// diffing. Export it with DOT, Mermaid or JSON.
+type Graph struct {
+	Nodes []GraphNode `json:"nodes"`
+	Edges []GraphEdge `json:"edges"`
+}
+
+// A bound type or a resolver in a Graph
+type GraphNode struct {
+	// Unique within the graph and stable across exports of the same bindings
+	ID string `json:"id"`
+	// GraphNodeType or GraphNodeResolver
+	Kind string `json:"kind"`
+	// The bound type, or the type the resolver returns
+	Type string `json:"type"`
+	// The name the binding was bound with, empty if unnamed
+	Name string `json:"name,omitempty"`
+	// The resolver's function name, or a description of the value or struct
+	// that was bound. Only set for resolvers.
+	Function string `json:"function,omitempty"`
+	// Where the resolver function is defined, if known. The file is given
+	// under its package's import path, e.g. "github.com/acme/app/server.go",
+	// so it's the same wherever the code was built.
+	File string `json:"file,omitempty"`
+	Line int    `json:"line,omitempty"`
+	// The lifetime the resolver was bound with. Only set for resolvers.
+	Lifetime string `json:"lifetime,omitempty"`
+	// The module that provided the resolver, if any
+	Module string `json:"module,omitempty"`
+	// A required dependency that has nothing bound to it
+	Missing bool `json:"missing,omitempty"`
+	// Part of a dependency cycle
+	Cycle bool `json:"cycle,omitempty"`
+}
+
+// A connection between two nodes in a Graph
+type GraphEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	// GraphEdgeBinding, GraphEdgeDependency or GraphEdgeAll
+	Kind string `json:"kind"`
+	// The index of the resolver argument. Only set for dependencies.
+	Arg int `json:"arg"`
+	// Whether the argument is left as its zero value when nothing is bound
+	Optional bool `json:"optional,omitempty"`
+	// Whether the argument is a Lazy or func resolved when it's used, rather
+	// than when the resolver is called
+	Lazy bool `json:"lazy,omitempty"`
+	// Part of a dependency cycle
+	Cycle bool `json:"cycle,omitempty"`
+}
+
+// Builds a graph of the bindings in the container without calling any
+// resolvers. Dependencies that are missing or part of a cycle are marked, the
+// same as Validate would report them. Bound types resolved from a parent
+// container appear as nodes, but the parent's resolvers don't.
+func (container *Container) Graph() *Graph {
+	container.lock.Lock()
+	defer container.lock.Unlock()
+
+	graph := &Graph{}
+	typeNodes := make(map[bindingKey]int)
+	addType := func(key bindingKey) string {
+		if idx, ok := typeNodes[key]; ok {
+			return graph.Nodes[idx].ID
+		}
+		typeNodes[key] = len(graph.Nodes)
+		graph.Nodes = append(graph.Nodes, GraphNode{
+			ID:   "type:" + key.String(),
+			Kind: GraphNodeType,
+			Type: key.bindingType.String(),
+			Name: key.name,
+		})
+		return graph.Nodes[len(graph.Nodes)-1].ID
+	}
+
+	// Edges from each key to the keys it depends on, for marking cycles
+	cycleEdges := make(map[[2]bindingKey]bool)
+	cycleKeys := make(map[bindingKey]bool)
+
+	for _, key := range container.sortedBindingKeys() {
+		typeID := addType(key)
+
+		for idx, bound := range container.bindingToResolver[key] {
+			resolverNode := describeResolver(bound)
+			resolverNode.ID = fmt.Sprintf("resolver:%v#%d", key, idx)
+			graph.Nodes = append(graph.Nodes, resolverNode)
+			graph.Edges = append(graph.Edges, GraphEdge{From: typeID, To: resolverNode.ID, Kind: GraphEdgeBinding})
+
+			resolverType := bound.resolver.Type()
+			for i := 0; i < resolverType.NumIn(); i++ {
+				// Contexts come from the resolve, so there's nothing to point to
+				if bound.argIsContext(i) {
+					continue
+				}
+				argKey := bound.argKey(i)
+
+				edge := GraphEdge{From: resolverNode.ID, To: addType(argKey), Kind: GraphEdgeDependency, Arg: i, Optional: bound.argIsOptional(i), Lazy: bound.argIsDeferred(i)}
+				if bound.argIsAll(i) {
+					edge.Kind = GraphEdgeAll
+				} else if !edge.Optional && !container.isBound(argKey, bound.module) {
+					graph.Nodes[typeNodes[argKey]].Missing = true
+				}
+				graph.Edges = append(graph.Edges, edge)
+			}
+
+			if cycle := container.findCycleFromResolver(bound, key); cycle != nil {
+				for i := 0; i < len(cycle)-1; i++ {
+					cycleKeys[cycle[i]] = true
+					cycleEdges[[2]bindingKey{cycle[i], cycle[i+1]}] = true
+				}
+			}
+		}
+	}
+
+	// Mark everything that makes up a cycle now that they're all found
+	nodeKeys := make(map[string]bindingKey)
+	for key, idx := range typeNodes {
+		nodeKeys[graph.Nodes[idx].ID] = key
+		graph.Nodes[idx].Cycle = cycleKeys[key]
+	}
+	resolverKeys := make(map[string]bindingKey)
+	for _, edge := range graph.Edges {
+		if edge.Kind == GraphEdgeBinding {
+			resolverKeys[edge.To] = nodeKeys[edge.From]
+		}
+	}
+	cycleResolvers := make(map[string]bool)
+	for idx, edge := range graph.Edges {
+		if edge.Kind == GraphEdgeBinding {
+			continue
+		}
+		if cycleEdges[[2]bindingKey{resolverKeys[edge.From], nodeKeys[edge.To]}] {
+			graph.Edges[idx].Cycle = true
+			cycleResolvers[edge.From] = true
+		}
+	}
+	for idx, edge := range graph.Edges {
+		if edge.Kind == GraphEdgeBinding && cycleResolvers[edge.To] {
+			graph.Edges[idx].Cycle = true
+		}
+	}
+	for idx, node := range graph.Nodes {
+		if cycleResolvers[node.ID] {
+			graph.Nodes[idx].Cycle = true
+		}
+	}
+
+	return graph
+}
+
+// Creates the node for a binding's resolver, without an ID
+func describeResolver(bound *binding) GraphNode {
+	node := GraphNode{
+		Kind:     GraphNodeResolver,
+		Type:     bound.resolver.Type().Out(0).String(),
+		Name:     bound.name,
+		Lifetime: bound.lifetime.String(),
+	}
+	if bound.module != nil {
+		node.Module = bound.module.name
+	}
+	node.Function, node.File, node.Line = bound.describe()
+	node.File = packageFile(node.Function, node.File)
+
+	return node
+}
+
+// Returns the resolver's function name and where it's defined. Values and
+// structs are described instead, since they don't have a resolver function of
+// their own.
+func (b *binding) describe() (function string, file string, line int) {
+	switch {
+	case b.value.IsValid():
+		return fmt.Sprintf("value (%v)", b.value.Type()), "", 0
+	case b.structType != nil:
+		return fmt.Sprintf("struct (%v)", b.structType), "", 0
+	}
+
+	fn := runtime.FuncForPC(b.boundResolver().Pointer())
+	if fn == nil {
+		return "", "", 0
+	}
+	file, line = fn.FileLine(fn.Entry())
+	return fn.Name(), file, line
+}
+
+// Returns the file a function is defined in under the import path of the
+// function's package rather than the directory it was built in. External test
+// packages are given the import path of the package they test, since they
+// share its directory.
+func packageFile(function string, file string) string {
+	if file == "" {
+		return ""
+	}
+
+	// Function names are the package path followed by a dot, and dots in the
+	// last element of the package path are escaped
+	lastSlash := strings.LastIndex(function, "/")
+	dot := strings.Index(function[lastSlash+1:], ".")
+	if dot < 0 {
+		return path.Base(file)
+	}
+	pkgPath := strings.ReplaceAll(function[:lastSlash+1+dot], "%2e", ".")
+	pkgPath = strings.TrimSuffix(pkgPath, "_test")
+
+	return path.Join(pkgPath, path.Base(file))
+}
+
+// Formats the graph as a Graphviz DOT digraph. Missing dependencies are drawn
+// dashed and red, cycles are drawn red, and slice arguments are drawn bold.
+func (graph *Graph) DOT() string {
+	var builder strings.Builder
+	builder.WriteString("digraph container {\n")
+	builder.WriteString("\trankdir=LR;\n")
+
+	for _, node := range graph.Nodes {
+		attrs := []string{"label=" + dotQuote(graphNodeLabel(node, "\n"))}
+		if node.Kind == GraphNodeType {
+			attrs = append(attrs, "shape=box")
+		} else {
+			attrs = append(attrs, "shape=ellipse")
+		}
+		if node.Missing {
+			attrs = append(attrs, "style=dashed", "color=red")
+		} else if node.Cycle {
+			attrs = append(attrs, "color=red")
+		}
+		fmt.Fprintf(&builder, "\t%v [%v];\n", dotQuote(node.ID), strings.Join(attrs, ", "))
+	}
+
+	for _, edge := range graph.Edges {
+		var attrs []string
+		switch edge.Kind {
+		case GraphEdgeBinding:
+			attrs = append(attrs, "style=dotted")
+		case GraphEdgeAll:
+			attrs = append(attrs, "label="+dotQuote(graphEdgeLabel(edge)), "style=bold")
+		default:
+			attrs = append(attrs, "label="+dotQuote(graphEdgeLabel(edge)))
+			if edge.Optional {
+				attrs = append(attrs, "style=dashed")
+			}
+		}
+		if edge.Cycle {
+			attrs = append(attrs, "color=red")
+		}
+		fmt.Fprintf(&builder, "\t%v -> %v [%v];\n", dotQuote(edge.From), dotQuote(edge.To), strings.Join(attrs, ", "))
+	}
+
+	builder.WriteString("}\n")
+	return builder.String()
+}
+
+// Formats the graph as a Mermaid flowchart. Missing dependencies and cycles
+// are styled red, and slice arguments are drawn as thick links.
+func (graph *Graph) Mermaid() string {
+	var builder strings.Builder
+	builder.WriteString("flowchart LR\n")
+	builder.WriteString("\tclassDef missing stroke:#f00,stroke-dasharray:5 5\n")
+	builder.WriteString("\tclassDef cycle stroke:#f00\n")
+
+	// Mermaid IDs can't contain most punctuation, so number the nodes instead
+	ids := make(map[string]string, len(graph.Nodes))
+	for idx, node := range graph.Nodes {
+		id := fmt.Sprintf("n%d", idx)
+		ids[node.ID] = id
+
+		label := mermaidQuote(graphNodeLabel(node, "<br>"))
+		if node.Kind == GraphNodeType {
+			fmt.Fprintf(&builder, "\t%v[%v]\n", id, label)
+		} else {
+			fmt.Fprintf(&builder, "\t%v(%v)\n", id, label)
+		}
+		if node.Missing {
+			fmt.Fprintf(&builder, "\tclass %v missing\n", id)
+		} else if node.Cycle {
+			fmt.Fprintf(&builder, "\tclass %v cycle\n", id)
+		}
+	}
+
+	for idx, edge := range graph.Edges {
+		switch edge.Kind {
+		case GraphEdgeBinding:
+			fmt.Fprintf(&builder, "\t%v -.-> %v\n", ids[edge.From], ids[edge.To])
+		case GraphEdgeAll:
+			fmt.Fprintf(&builder, "\t%v ==>|%v| %v\n", ids[edge.From], mermaidQuote(graphEdgeLabel(edge)), ids[edge.To])
+		default:
+			fmt.Fprintf(&builder, "\t%v -->|%v| %v\n", ids[edge.From], mermaidQuote(graphEdgeLabel(edge)), ids[edge.To])
+		}
+		if edge.Cycle {
+			fmt.Fprintf(&builder, "\tlinkStyle %d stroke:#f00\n", idx)
+		}
+	}
+
+	return builder.String()
+}
+
+// Labels a dependency edge with the resolver argument it's for, e.g.
+// "arg 1 (all, lazy)"
+func graphEdgeLabel(edge GraphEdge) string {
+	var modifiers []string
+	if edge.Kind == GraphEdgeAll {
+		modifiers = append(modifiers, "all")
+	}
+	if edge.Lazy {
+		modifiers = append(modifiers, "lazy")
+	}
+	if len(modifiers) == 0 {
+		return fmt.Sprintf("arg %d", edge.Arg)
+	}
+	return fmt.Sprintf("arg %d (%v)", edge.Arg, strings.Join(modifiers, ", "))
+}
+
+// Formats the graph as indented JSON. Nodes and edges are in a stable order,
+// so the output can be diffed between builds.
+func (graph *Graph) JSON() ([]byte, error) {
+	return json.MarshalIndent(graph, "", "  ")
+}
+
+// Returns the text to display for a node, with lines joined by newline
+func graphNodeLabel(node GraphNode, newline string) string {
+	if node.Kind == GraphNodeType {
+		if node.Name == "" {
+			return node.Type
+		}
+		return fmt.Sprintf("%v(name=%v)", node.Type, node.Name)
+	}
+
+	lines := []string{node.Function}
+	if node.File != "" {
+		lines = append(lines, fmt.Sprintf("%v:%d", node.File, node.Line))
+	}
+	lines = append(lines, node.Lifetime)
+	if node.Module != "" {
+		lines = append(lines, "module "+node.Module)
+	}
+	return strings.Join(lines, newline)
+}
+
+// Quotes a DOT ID or label
+func dotQuote(text string) string {
+	text = strings.ReplaceAll(text, `\`, `\\`)
+	text = strings.ReplaceAll(text, `"`, `\"`)
+	text = strings.ReplaceAll(text, "\n", `\n`)
+	return `"` + text + `"`
+}
+
+// Quotes a Mermaid label
+func mermaidQuote(text string) string {
+	return `"` + strings.ReplaceAll(text, `"`, "#quot;") + `"`
+}
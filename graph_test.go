@@ -0,0 +1,182 @@
+package container_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gobros/container"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraphNodesAndEdges(t *testing.T) {
+	// Given
+	c := &container.Container{}
+	container.MustBindInstance[PrimaryIDGiver](c, NewTestStruct1)
+	container.MustBindInstance[SecondaryIDGiver](c, NewTestStruct2)
+	container.MustBindInstance[IDAggregator](c, NewTestIDAggregatorStruct)
+
+	// When
+	graph := c.Graph()
+
+	// Then
+	assert.Len(t, graph.Nodes, 6)
+	assert.Len(t, graph.Edges, 5)
+
+	aggregator := findGraphNode(t, graph, "resolver:container_test.IDAggregator#0")
+	assert.Equal(t, container.GraphNodeResolver, aggregator.Kind)
+	assert.Equal(t, "github.com/gobros/container_test.NewTestIDAggregatorStruct", aggregator.Function)
+	assert.Equal(t, "github.com/gobros/container/container_test.go", aggregator.File)
+	assert.NotZero(t, aggregator.Line)
+	assert.Equal(t, "Singleton", aggregator.Lifetime)
+
+	assert.Contains(t, graph.Edges, container.GraphEdge{
+		From: aggregator.ID, To: "type:container_test.PrimaryIDGiver", Kind: container.GraphEdgeAll, Arg: 0,
+	})
+	assert.Contains(t, graph.Edges, container.GraphEdge{
+		From: aggregator.ID, To: "type:container_test.SecondaryIDGiver", Kind: container.GraphEdgeDependency, Arg: 1,
+	})
+	assert.Contains(t, graph.Edges, container.GraphEdge{
+		From: "type:container_test.IDAggregator", To: aggregator.ID, Kind: container.GraphEdgeBinding,
+	})
+	for _, node := range graph.Nodes {
+		assert.False(t, node.Missing)
+		assert.False(t, node.Cycle)
+	}
+}
+
+func TestGraphMissing(t *testing.T) {
+	// Given
+	c := &container.Container{}
+	container.MustBindInstance[IDAggregator](c, NewTestIDAggregatorStruct)
+
+	// When
+	graph := c.Graph()
+
+	// Then
+	assert.True(t, findGraphNode(t, graph, "type:container_test.SecondaryIDGiver").Missing)
+	assert.False(t, findGraphNode(t, graph, "type:container_test.PrimaryIDGiver").Missing)
+	assert.Contains(t, graph.DOT(), `"type:container_test.SecondaryIDGiver" [label="container_test.SecondaryIDGiver", shape=box, style=dashed, color=red];`)
+	assert.Contains(t, graph.Mermaid(), "class n3 missing")
+}
+
+func TestGraphCycle(t *testing.T) {
+	// Given
+	setup()
+	bindCycle(t, container.Singleton)
+	container.MustBind[PrimaryIDGiver](NewTestStruct1)
+
+	// When
+	graph := container.Global.Graph()
+
+	// Then
+	var cycleNodes, cycleEdges int
+	for _, node := range graph.Nodes {
+		if node.Cycle {
+			cycleNodes++
+		}
+	}
+	for _, edge := range graph.Edges {
+		if edge.Cycle {
+			cycleEdges++
+		}
+	}
+	assert.Equal(t, 6, cycleNodes)
+	assert.Equal(t, 6, cycleEdges)
+	assert.False(t, findGraphNode(t, graph, "type:container_test.PrimaryIDGiver").Cycle)
+	assert.Contains(t, graph.DOT(), "color=red")
+	assert.Contains(t, graph.Mermaid(), "stroke:#f00")
+
+	cleanup()
+}
+
+func TestGraphValuesStructsAndNames(t *testing.T) {
+	// Given
+	c := &container.Container{}
+	container.MustBindValueInstance[SecondaryIDGiver](c, &TestStruct2{}, container.WithName("fixed"))
+	container.MustBindStructInstance[IDAggregator, injectAggregator](c, container.WithLifetime(container.Transient))
+
+	// When
+	graph := c.Graph()
+
+	// Then
+	value := findGraphNode(t, graph, "resolver:container_test.SecondaryIDGiver(name=fixed)#0")
+	assert.Equal(t, "value (*container_test.TestStruct2)", value.Function)
+	assert.Equal(t, "fixed", value.Name)
+	assert.Empty(t, value.File)
+	built := findGraphNode(t, graph, "resolver:container_test.IDAggregator#0")
+	assert.Equal(t, "struct (container_test.injectAggregator)", built.Function)
+	assert.Equal(t, "Transient", built.Lifetime)
+	assert.True(t, findGraphNode(t, graph, "type:container_test.SecondaryIDGiver").Missing)
+}
+
+func TestGraphJSONStable(t *testing.T) {
+	// Given
+	c := &container.Container{}
+	container.MustBindInstance[IDAggregator](c, NewTestIDAggregatorStruct)
+	container.MustBindInstance[SecondaryIDGiver](c, NewTestStruct2)
+	container.MustBindInstance[PrimaryIDGiver](c, NewTestStruct1)
+	container.MustBindInstance[PrimaryIDGiver](c, NewTestStruct2)
+
+	// When
+	first, firstErr := c.Graph().JSON()
+	second, secondErr := c.Graph().JSON()
+
+	// Then
+	assert.NoError(t, firstErr)
+	assert.NoError(t, secondErr)
+	assert.Equal(t, string(first), string(second))
+
+	var decoded container.Graph
+	assert.NoError(t, json.Unmarshal(first, &decoded))
+	assert.Equal(t, c.Graph(), &decoded)
+	assert.Equal(t, "type:container_test.IDAggregator", decoded.Nodes[0].ID)
+	assert.Equal(t, "resolver:container_test.PrimaryIDGiver#1", decoded.Nodes[5].ID)
+}
+
+func TestGraphJSONIndependentOfCheckout(t *testing.T) {
+	// Given
+	c := &container.Container{}
+	container.MustBindInstance[PrimaryIDGiver](c, NewTestStruct1)
+	container.MustBindInstance[SecondaryIDGiver](c, func() *TestStruct2 { return NewTestStruct2() })
+	dir, err := os.Getwd()
+	assert.NoError(t, err)
+
+	// When
+	encoded, err := c.Graph().JSON()
+
+	// Then
+	assert.NoError(t, err)
+	assert.NotContains(t, string(encoded), filepath.ToSlash(dir))
+	for _, node := range c.Graph().Nodes {
+		if node.Kind == container.GraphNodeResolver {
+			assert.True(t, strings.HasPrefix(node.File, "github.com/gobros/container/"), node.File)
+		}
+	}
+}
+
+func TestGraphEmpty(t *testing.T) {
+	// Given
+	c := &container.Container{}
+
+	// When
+	graph := c.Graph()
+
+	// Then
+	assert.Empty(t, graph.Nodes)
+	assert.Equal(t, "digraph container {\n\trankdir=LR;\n}\n", graph.DOT())
+	assert.True(t, strings.HasPrefix(graph.Mermaid(), "flowchart LR\n"))
+}
+
+// Returns the node in the graph with the ID, failing the test if there isn't one
+func findGraphNode(t *testing.T, graph *container.Graph, id string) container.GraphNode {
+	for _, node := range graph.Nodes {
+		if node.ID == id {
+			return node
+		}
+	}
+	t.Fatalf("no node with ID (%v) in graph", id)
+	return container.GraphNode{}
+}
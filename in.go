@@ -0,0 +1,227 @@
+package container
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Embedded in a struct to make it a parameter struct. A resolver or invoked
+// function that takes a parameter struct, rather than a pointer to one, gets
+// each of its exported fields filled from the container instead of the struct
+// itself being resolved. Fields are resolved the same way resolver arguments
+// are, and can be tagged the same way injected fields are to give the name of
+// the binding to resolve from and the optional or all modifiers, e.g.
+// `inject:"replica,optional"`. Parameter structs can't be nested.
+//
+//	type ServerParams struct {
+//		container.In
+//
+//		DB       *sql.DB   `inject:"replica"`
+//		Cache    Cache     `inject:",optional"`
+//		Handlers []Handler `inject:",all"`
+//	}
+//
+//	func NewServer(params ServerParams) *Server
+type In struct{}
+
+var inType = reflect.TypeOf(In{})
+
+// An argument of a resolver built by newParamResolver, either a field of one
+// of the wrapped resolver's parameter structs or one of its other arguments
+type paramArg struct {
+	// Index of the wrapped resolver's argument the argument is passed to
+	param int
+	// The field of the parameter struct the argument fills, nil if the
+	// argument is passed as is
+	field *injectField
+}
+
+// A resolver that takes each field of another resolver's parameter structs
+// as an argument of its own, so they can be resolved, validated and cycle
+// checked like any other resolver's arguments
+type paramResolver struct {
+	resolver reflect.Value
+	args     []paramArg
+}
+
+// Returns true if the type is a parameter struct
+func isParamStruct(paramType reflect.Type) bool {
+	if paramType.Kind() != reflect.Struct {
+		return false
+	}
+	for i := 0; i < paramType.NumField(); i++ {
+		if field := paramType.Field(i); field.Anonymous && field.Type == inType {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns true if any of the function's parameters are parameter structs
+func hasParamStructs(functionType reflect.Type) bool {
+	for i := 0; i < functionType.NumIn(); i++ {
+		if isParamStruct(functionType.In(i)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Finds the fields of a parameter struct to be filled and validates them.
+// Every exported field is filled, tagged or not.
+func parseParamFields(structType reflect.Type) ([]injectField, error) {
+	var fields []injectField
+
+	for i := 0; i < structType.NumField(); i++ {
+		structField := structType.Field(i)
+		if structField.Anonymous && structField.Type == inType {
+			continue
+		}
+
+		if !structField.IsExported() {
+			return nil, fmt.Errorf("field (%v) of parameter struct (%v) must be exported to be filled", structField.Name, structType)
+		}
+		if !isResolvableParam(structField.Type) {
+			return nil, fmt.Errorf("field (%v) of parameter struct (%v) must be of type pointer, interface, slice, Optional, Lazy, or func() (T, error)", structField.Name, structType)
+		}
+
+		field, err := parseInjectTag(structField, structField.Tag.Get(injectTag))
+		if err != nil {
+			return nil, fmt.Errorf("field (%v) of parameter struct (%v) %w", structField.Name, structType, err)
+		}
+		field.index = i
+
+		fields = append(fields, field)
+	}
+
+	return fields, nil
+}
+
+// Validates the parameter structs the function takes, if any
+func validateParamStructs(functionType reflect.Type) error {
+	for i := 0; i < functionType.NumIn(); i++ {
+		if paramType := functionType.In(i); isParamStruct(paramType) {
+			if _, err := parseParamFields(paramType); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Builds a resolver that fills the parameter structs of the resolver and calls
+// it. Built for every bind and invoke rather than cached, since resolvers are
+// often closures that are only used once. The resolver must already be
+// validated.
+func newParamResolver(resolver reflect.Value) *paramResolver {
+	resolverType := resolver.Type()
+	var args []paramArg
+	var argTypes []reflect.Type
+	for i := 0; i < resolverType.NumIn(); i++ {
+		paramType := resolverType.In(i)
+		if !isParamStruct(paramType) {
+			args = append(args, paramArg{param: i})
+			argTypes = append(argTypes, paramType)
+			continue
+		}
+
+		// Validated along with the resolver
+		fields, _ := parseParamFields(paramType)
+		for idx := range fields {
+			args = append(args, paramArg{param: i, field: &fields[idx]})
+			argTypes = append(argTypes, paramType.Field(fields[idx].index).Type)
+		}
+	}
+
+	outTypes := make([]reflect.Type, resolverType.NumOut())
+	for i := range outTypes {
+		outTypes[i] = resolverType.Out(i)
+	}
+
+	wrapperType := reflect.FuncOf(argTypes, outTypes, false)
+	wrapper := reflect.MakeFunc(wrapperType, func(values []reflect.Value) []reflect.Value {
+		params := make([]reflect.Value, resolverType.NumIn())
+		for i := range params {
+			if isParamStruct(resolverType.In(i)) {
+				params[i] = reflect.New(resolverType.In(i)).Elem()
+			}
+		}
+		for idx, arg := range args {
+			if arg.field == nil {
+				params[arg.param] = values[idx]
+			} else {
+				params[arg.param].Field(arg.field.index).Set(values[idx])
+			}
+		}
+		return resolver.Call(params)
+	})
+
+	return &paramResolver{resolver: wrapper, args: args}
+}
+
+// Creates a binding for a resolver that takes parameter structs. The binding
+// calls the resolver built by newParamResolver, and remembers the resolver it
+// wraps to describe and identify it.
+func newParamBinding(resolver reflect.Value) *binding {
+	built := newParamResolver(resolver)
+	return &binding{resolver: built.resolver, lifetime: Singleton, original: resolver, fillsParams: true, paramArgs: built.args}
+}
+
+// Moves the argument names and optional arguments given by bind options from
+// the wrapped resolver's argument indexes to the indexes of the arguments
+// they're passed as, then adds the names and modifiers the parameter structs'
+// fields are tagged with. Does nothing if the binding's resolver doesn't take
+// parameter structs.
+func (b *binding) expandParamArgs() error {
+	if b.paramArgs == nil {
+		return nil
+	}
+
+	argNames := make(map[int]string)
+	for idx, name := range b.argNames {
+		argIdx, err := b.paramArgIndex(idx, "named")
+		if err != nil {
+			return err
+		}
+		argNames[argIdx] = name
+	}
+	argOptional := make(map[int]bool)
+	for idx, optional := range b.argOptional {
+		argIdx, err := b.paramArgIndex(idx, "optional")
+		if err != nil {
+			return err
+		}
+		argOptional[argIdx] = optional
+	}
+
+	for idx, arg := range b.paramArgs {
+		if arg.field == nil {
+			continue
+		}
+		if arg.field.name != "" {
+			argNames[idx] = arg.field.name
+		}
+		if arg.field.optional {
+			argOptional[idx] = true
+		}
+	}
+	b.argNames, b.argOptional, b.paramArgs = argNames, argOptional, nil
+
+	return nil
+}
+
+// Returns the index of the argument that's passed to the wrapped resolver's
+// argument at the index. Fails if the index is out of range or is a
+// parameter struct, since those are split into one argument per field.
+func (b *binding) paramArgIndex(idx int, kind string) (int, error) {
+	numIn := b.original.Type().NumIn()
+	if idx < 0 || idx >= numIn {
+		return 0, fmt.Errorf("resolver error, %v argument index (%v) is out of range for a resolver with (%v) arguments", kind, idx, numIn)
+	}
+	for argIdx, arg := range b.paramArgs {
+		if arg.param == idx && arg.field == nil {
+			return argIdx, nil
+		}
+	}
+	return 0, fmt.Errorf("resolver error, %v argument index (%v) is a parameter struct, tag its fields instead", kind, idx)
+}
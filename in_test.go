@@ -0,0 +1,247 @@
+package container_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/gobros/container"
+	"github.com/stretchr/testify/assert"
+)
+
+type aggregatorParams struct {
+	container.In
+
+	Primaries []PrimaryIDGiver `inject:",all"`
+	Secondary SecondaryIDGiver
+	Named     PrimaryIDGiver `inject:"named"`
+	Missing   *TestStruct1   `inject:",optional"`
+	Maybe     container.Optional[*TestStruct2]
+}
+
+func newParamsAggregator(params aggregatorParams) *TestIDAggregatorStruct {
+	return NewTestIDAggregatorStruct(append(params.Primaries, params.Named), params.Secondary)
+}
+
+func TestParamStructFilled(t *testing.T) {
+	// Given
+	setup()
+
+	container.MustBind[PrimaryIDGiver](NewTestStruct1)
+	container.MustBind[PrimaryIDGiver](NewTestStruct2, container.WithName("named"))
+	container.MustBind[SecondaryIDGiver](NewTestStruct1, container.WithLifetime(container.Transient))
+	var received aggregatorParams
+	container.MustBind[IDAggregator](func(params aggregatorParams) *TestIDAggregatorStruct {
+		received = params
+		return newParamsAggregator(params)
+	})
+
+	// When
+	aggregator, err := container.Resolve[IDAggregator]()
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, []ID{{Name: TestStruct1Name, Number: 1}, {Name: TestStruct2Name, Number: 1}}, aggregator.GivePrimaryIDs())
+	assert.Equal(t, ID{Name: TestStruct1Name, Number: 2}, aggregator.GiveSecondaryID())
+	assert.Nil(t, received.Missing)
+	assert.False(t, received.Maybe.Present)
+
+	cleanup()
+}
+
+func TestParamStructWithPositionalArguments(t *testing.T) {
+	// Given
+	setup()
+
+	type params struct {
+		container.In
+
+		Secondary SecondaryIDGiver
+	}
+	container.MustBind[PrimaryIDGiver](NewTestStruct1)
+	container.MustBind[PrimaryIDGiver](NewTestStruct2, container.WithName("named"))
+	container.MustBind[SecondaryIDGiver](NewTestStruct1)
+
+	// When
+	err := container.Bind[IDAggregator](func(primaries []PrimaryIDGiver, params params, named PrimaryIDGiver) *TestIDAggregatorStruct {
+		return NewTestIDAggregatorStruct(append(primaries, named), params.Secondary)
+	}, container.WithArgName(2, "named"))
+	aggregator, resolveErr := container.Resolve[IDAggregator]()
+
+	// Then
+	assert.NoError(t, err)
+	assert.NoError(t, resolveErr)
+	assert.Equal(t, []ID{{Name: TestStruct1Name, Number: 1}, {Name: TestStruct2Name, Number: 1}}, aggregator.GivePrimaryIDs())
+
+	cleanup()
+}
+
+func TestParamStructArgumentOptionRejected(t *testing.T) {
+	// Given
+	setup()
+
+	// When
+	namedErr := container.Bind[IDAggregator](newParamsAggregator, container.WithArgName(0, "named"))
+	optionalErr := container.Bind[IDAggregator](newParamsAggregator, container.WithOptionalArg(1))
+
+	// Then
+	assert.ErrorIs(t, namedErr, container.ErrInvalidResolver)
+	assert.ErrorContains(t, namedErr, "named argument index (0) is a parameter struct")
+	assert.ErrorIs(t, optionalErr, container.ErrInvalidResolver)
+	assert.ErrorContains(t, optionalErr, "optional argument index (1) is out of range")
+	assert.False(t, container.Has[IDAggregator]())
+
+	cleanup()
+}
+
+func TestParamStructInvalidFields(t *testing.T) {
+	// Given
+	setup()
+
+	type unexportedParams struct {
+		container.In
+
+		secondary SecondaryIDGiver
+	}
+	type badTypeParams struct {
+		container.In
+
+		Count int
+	}
+	type badTagParams struct {
+		container.In
+
+		Secondary SecondaryIDGiver `inject:",all"`
+	}
+
+	// When
+	unexportedErr := container.Bind[SecondaryIDGiver](func(unexportedParams) *TestStruct1 { return nil })
+	badTypeErr := container.Bind[SecondaryIDGiver](func(badTypeParams) *TestStruct1 { return nil })
+	badTagErr := container.Bind[SecondaryIDGiver](func(badTagParams) *TestStruct1 { return nil })
+
+	// Then
+	assert.ErrorIs(t, unexportedErr, container.ErrInvalidResolver)
+	assert.ErrorContains(t, unexportedErr, "field (secondary) of parameter struct (container_test.unexportedParams) must be exported")
+	assert.ErrorIs(t, badTypeErr, container.ErrInvalidResolver)
+	assert.ErrorContains(t, badTypeErr, "field (Count) of parameter struct (container_test.badTypeParams) must be of type pointer")
+	assert.ErrorIs(t, badTagErr, container.ErrInvalidResolver)
+	assert.ErrorContains(t, badTagErr, "field (Secondary) of parameter struct (container_test.badTagParams) is tagged all but isn't a slice")
+
+	cleanup()
+}
+
+func TestPlainStructParameterRejected(t *testing.T) {
+	// Given
+	setup()
+
+	// When
+	err := container.Bind[SecondaryIDGiver](func(ID) *TestStruct1 { return nil })
+
+	// Then
+	assert.ErrorIs(t, err, container.ErrInvalidResolver)
+
+	cleanup()
+}
+
+func TestParamStructMissingDependency(t *testing.T) {
+	// Given
+	setup()
+
+	container.MustBind[IDAggregator](newParamsAggregator)
+
+	// When
+	validateErr := container.Global.Validate()
+	_, resolveErr := container.Resolve[IDAggregator]()
+
+	// Then
+	assert.ErrorIs(t, validateErr, container.ErrNotBound)
+	var notBound *container.NotBoundError
+	assert.ErrorAs(t, resolveErr, &notBound)
+	assert.Equal(t, []reflect.Type{
+		reflect.TypeOf((*IDAggregator)(nil)).Elem(),
+		reflect.TypeOf((*SecondaryIDGiver)(nil)).Elem(),
+	}, notBound.Path)
+
+	cleanup()
+}
+
+func TestParamStructDescribesBoundResolver(t *testing.T) {
+	// Given
+	setup()
+
+	resolverErr := errors.New("failed")
+	container.MustBind[PrimaryIDGiver](NewTestStruct1)
+	container.MustBind[PrimaryIDGiver](NewTestStruct2, container.WithName("named"))
+	container.MustBind[SecondaryIDGiver](NewTestStruct1)
+	container.MustBind[IDAggregator](newParamsAggregator)
+	container.MustBind[IDAggregator](newParamsAggregator)
+	container.MustBind[*TestIDAggregatorStruct](func(params aggregatorParams) (*TestIDAggregatorStruct, error) {
+		return nil, resolverErr
+	})
+
+	// When
+	info := container.Global.Bindings()
+	_, err := container.Resolve[*TestIDAggregatorStruct]()
+
+	// Then
+	var aggregator container.BindingInfo
+	for _, binding := range info {
+		if binding.Type == reflect.TypeOf((*IDAggregator)(nil)).Elem() {
+			aggregator = binding
+		}
+	}
+	assert.Len(t, aggregator.Resolvers, 1)
+	assert.Equal(t, "github.com/gobros/container_test.newParamsAggregator", aggregator.Resolvers[0].Function)
+	assert.Len(t, aggregator.Resolvers[0].Dependencies, 5)
+	assert.Equal(t, "named", aggregator.Resolvers[0].Dependencies[2].Name)
+	assert.True(t, aggregator.Resolvers[0].Dependencies[3].Optional)
+	var resolveErr *container.ResolverError
+	assert.ErrorAs(t, err, &resolveErr)
+	assert.ErrorIs(t, err, resolverErr)
+	assert.Equal(t, reflect.Func, resolveErr.Resolver.Kind())
+	assert.Equal(t, reflect.TypeOf(aggregatorParams{}), resolveErr.Resolver.Type().In(0))
+
+	cleanup()
+}
+
+func TestParamStructResolverSharesSingleton(t *testing.T) {
+	// Given
+	setup()
+
+	container.MustBind[PrimaryIDGiver](NewTestStruct2, container.WithName("named"))
+	container.MustBind[SecondaryIDGiver](NewTestStruct1)
+	container.MustBind[IDAggregator](newParamsAggregator)
+	container.MustBind[*TestIDAggregatorStruct](newParamsAggregator)
+
+	// When
+	aggregator := container.MustResolve[IDAggregator]()
+	concrete := container.MustResolve[*TestIDAggregatorStruct]()
+
+	// Then
+	assert.Same(t, aggregator, concrete)
+
+	cleanup()
+}
+
+func TestInvokeParamStruct(t *testing.T) {
+	// Given
+	setup()
+
+	type params struct {
+		container.In
+
+		Primary PrimaryIDGiver
+	}
+	container.MustBind[PrimaryIDGiver](NewTestStruct1)
+
+	// When
+	id, err := container.InvokeResult[ID](func(params params) ID {
+		return params.Primary.GivePrimaryID()
+	})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, ID{Name: TestStruct1Name, Number: 1}, id)
+
+	cleanup()
+}
@@ -0,0 +1,217 @@
+package container
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// The struct tag that marks a field to be populated by the container. The tag
+// value is an optional binding name followed by optional modifiers, e.g.
+// `inject:""`, `inject:"replica"` or `inject:"replica,optional"`. The all
+// modifier marks a slice field that receives every concrete bound to its
+// element type, which slice fields always do, so it only documents intent.
+const injectTag = "inject"
+
+// A struct field populated by the container
+type injectField struct {
+	// Index of the field in the struct
+	index int
+	// Name of the binding to resolve the field from, empty if unnamed
+	name string
+	// Whether the field is left as its zero value when nothing is bound
+	optional bool
+}
+
+// A resolver built for a struct type by BindStruct, along with the fields it
+// populates in the order they're passed to it
+type structResolver struct {
+	resolver reflect.Value
+	fields   []injectField
+}
+
+// Resolvers built for struct types, keyed by struct type. Reused so binding
+// the same struct type twice is treated as binding the same resolver.
+var structResolvers sync.Map
+
+// Populates the tagged fields of a pointer to a struct from the container.
+// Fields are tagged with `inject:""`, optionally giving the name of the
+// binding to resolve from and the optional modifier, e.g.
+// `inject:"replica,optional"`. Uses the global container instance.
+func Inject(target any) error {
+	return InjectInstance(Global, target)
+}
+
+// Populates the tagged fields of a pointer to a struct from the container.
+// Fields are tagged with `inject:""`, optionally giving the name of the
+// binding to resolve from and the optional modifier, e.g.
+// `inject:"replica,optional"`. Uses the provided container instance.
+func InjectInstance(container *Container, target any) error {
+	targetValue := reflect.ValueOf(target)
+	if targetValue.Kind() != reflect.Ptr || targetValue.IsNil() || targetValue.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("inject error, target must be a non-nil pointer to a struct")
+	}
+
+	structType := targetValue.Elem().Type()
+	fields, err := parseInjectFields(structType)
+	if err != nil {
+		return err
+	}
+
+	key := bindingKey{bindingType: targetValue.Type()}
+	for _, field := range fields {
+		fieldType := structType.Field(field.index)
+		fieldKey := bindingKey{bindingType: dependencyType(fieldType.Type), name: field.name}
+
+		fieldValue, err := resolveDependency(container, resolution{}, fieldType.Type, fieldKey, field.optional, key)
+		if err != nil {
+			return fmt.Errorf("inject error, failed to inject field (%v) of (%v): %w", fieldType.Name, structType, err)
+		}
+		targetValue.Elem().Field(field.index).Set(fieldValue)
+	}
+
+	return nil
+}
+
+// Binds a struct type to a bound type without a hand-written resolver. When
+// resolved, a new S is allocated and its tagged fields are populated the same
+// way Inject does. The bound type must be satisfied by *S. Uses the global
+// container instance.
+func BindStruct[T any, S any](opts ...BindOption) error {
+	return BindStructInstance[T, S](Global, opts...)
+}
+
+// Binds a struct type to a bound type without a hand-written resolver. When
+// resolved, a new S is allocated and its tagged fields are populated the same
+// way Inject does. The bound type must be satisfied by *S. Uses the provided
+// container instance.
+func BindStructInstance[T any, S any](container *Container, opts ...BindOption) error {
+	bindingType := getBindingType[T]()
+
+	newBinding, err := newStructBinding(bindingType, getBindingType[S]())
+	if err != nil {
+		return err
+	}
+
+	return addBinding(container, bindingType, newBinding, opts)
+}
+
+// Creates a binding that builds the struct type, ensuring the struct type can
+// be injected and used as the bound type
+func newStructBinding(bindingType reflect.Type, structType reflect.Type) (*binding, error) {
+	if structType.Kind() != reflect.Struct {
+		return nil, &ValidationError{Type: bindingType, Err: fmt.Errorf("resolver error, S must be a struct type")}
+	}
+
+	built, err := getStructResolver(structType)
+	if err != nil {
+		return nil, &ValidationError{Type: bindingType, Err: err}
+	}
+
+	// Ensure the generated resolver has a chance of functioning
+	err = validateResolver(built.resolver, bindingType)
+	if err != nil {
+		return nil, &ValidationError{Type: bindingType, Resolver: built.resolver, Err: err}
+	}
+
+	newBinding := &binding{resolver: built.resolver, lifetime: Singleton, structType: structType}
+	for idx, field := range built.fields {
+		if field.name != "" {
+			if newBinding.argNames == nil {
+				newBinding.argNames = make(map[int]string)
+			}
+			newBinding.argNames[idx] = field.name
+		}
+		if field.optional {
+			if newBinding.argOptional == nil {
+				newBinding.argOptional = make(map[int]bool)
+			}
+			newBinding.argOptional[idx] = true
+		}
+	}
+
+	return newBinding, nil
+}
+
+// Returns the resolver that builds the struct type, creating it the first time
+// it's asked for
+func getStructResolver(structType reflect.Type) (*structResolver, error) {
+	if built, ok := structResolvers.Load(structType); ok {
+		return built.(*structResolver), nil
+	}
+
+	fields, err := parseInjectFields(structType)
+	if err != nil {
+		return nil, err
+	}
+
+	// The resolver takes one argument per field so it can be resolved,
+	// validated and cycle checked like any other resolver
+	argTypes := make([]reflect.Type, len(fields))
+	for idx, field := range fields {
+		argTypes[idx] = structType.Field(field.index).Type
+	}
+	resolverType := reflect.FuncOf(argTypes, []reflect.Type{reflect.PointerTo(structType)}, false)
+	resolver := reflect.MakeFunc(resolverType, func(args []reflect.Value) []reflect.Value {
+		built := reflect.New(structType)
+		for idx, field := range fields {
+			built.Elem().Field(field.index).Set(args[idx])
+		}
+		return []reflect.Value{built}
+	})
+
+	built, _ := structResolvers.LoadOrStore(structType, &structResolver{resolver: resolver, fields: fields})
+	return built.(*structResolver), nil
+}
+
+// Finds the fields of a struct type tagged to be injected and validates them
+func parseInjectFields(structType reflect.Type) ([]injectField, error) {
+	var fields []injectField
+
+	for i := 0; i < structType.NumField(); i++ {
+		structField := structType.Field(i)
+		tag, ok := structField.Tag.Lookup(injectTag)
+		if !ok {
+			continue
+		}
+
+		if !structField.IsExported() {
+			return nil, fmt.Errorf("inject error, field (%v) of (%v) must be exported to be injected", structField.Name, structType)
+		}
+		if !isResolvableParam(structField.Type) {
+			return nil, fmt.Errorf("inject error, field (%v) of (%v) must be of type pointer, interface, slice, Optional, Lazy, or func() (T, error)", structField.Name, structType)
+		}
+
+		field, err := parseInjectTag(structField, tag)
+		if err != nil {
+			return nil, fmt.Errorf("inject error, field (%v) of (%v) %w", structField.Name, structType, err)
+		}
+		field.index = i
+
+		fields = append(fields, field)
+	}
+
+	return fields, nil
+}
+
+// Parses the binding name and modifiers from a field's inject tag. The
+// returned error describes what's wrong with the field, to follow its name.
+func parseInjectTag(structField reflect.StructField, tag string) (injectField, error) {
+	var field injectField
+	parts := strings.Split(tag, ",")
+	field.name = parts[0]
+	for _, modifier := range parts[1:] {
+		switch modifier {
+		case "optional":
+			field.optional = true
+		case "all":
+			if deferredElem(structField.Type).Kind() != reflect.Slice {
+				return field, fmt.Errorf("is tagged all but isn't a slice")
+			}
+		default:
+			return field, fmt.Errorf("has unknown inject modifier (%v)", modifier)
+		}
+	}
+	return field, nil
+}
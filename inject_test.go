@@ -0,0 +1,235 @@
+package container_test
+
+import (
+	"testing"
+
+	"github.com/gobros/container"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInjectExistingValue(t *testing.T) {
+	// Given
+	setup()
+
+	container.MustBind[PrimaryIDGiver](NewTestStruct1)
+	container.MustBind[PrimaryIDGiver](NewTestStruct2)
+	container.MustBind[SecondaryIDGiver](NewTestStruct2)
+	target := &injectTarget{NotInjected: "untouched"}
+
+	// When
+	err := container.Inject(target)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Len(t, target.Primaries, 2)
+	assert.Equal(t, TestStruct2Name, target.Secondary.GiveSecondaryID().Name)
+	assert.Nil(t, target.Optional)
+	assert.Equal(t, "untouched", target.NotInjected)
+
+	cleanup()
+}
+
+func TestInjectNamedAndOptional(t *testing.T) {
+	// Given
+	setup()
+
+	container.MustBind[SecondaryIDGiver](NewTestStruct2)
+	container.MustBind[PrimaryIDGiver](NewTestStruct1, container.WithName("extra"))
+	target := &injectTarget{}
+
+	// When
+	err := container.InjectInstance(container.Global, target)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Empty(t, target.Primaries)
+	assert.NotNil(t, target.Optional)
+	assert.Equal(t, TestStruct1Name, target.Optional.GivePrimaryID().Name)
+
+	cleanup()
+}
+
+func TestInjectMissingDependency(t *testing.T) {
+	// Given
+	setup()
+	target := &injectTarget{}
+
+	// When
+	err := container.Inject(target)
+
+	// Then
+	assert.ErrorIs(t, err, container.ErrNotBound)
+	assert.Contains(t, err.Error(), "field (Secondary)")
+
+	cleanup()
+}
+
+func TestInjectBadTargets(t *testing.T) {
+	// Given
+	setup()
+
+	type unexportedField struct {
+		secondary SecondaryIDGiver `inject:""`
+	}
+	type badFieldType struct {
+		Count int `inject:""`
+	}
+	type badModifier struct {
+		Secondary SecondaryIDGiver `inject:",sometimes"`
+	}
+
+	// When & Then
+	assert.Error(t, container.Inject(injectTarget{}))
+	assert.Error(t, container.Inject((*injectTarget)(nil)))
+	assert.Error(t, container.Inject(&unexportedField{}))
+	assert.Error(t, container.Inject(&badFieldType{}))
+	assert.Error(t, container.Inject(&badModifier{}))
+
+	cleanup()
+}
+
+func TestBindStruct(t *testing.T) {
+	// Given
+	setup()
+
+	container.MustBind[PrimaryIDGiver](NewTestStruct1)
+	container.MustBind[SecondaryIDGiver](NewTestStruct2)
+	err := container.BindStruct[IDAggregator, injectAggregator]()
+	assert.NoError(t, err)
+
+	// When
+	agg, err := container.Resolve[IDAggregator]()
+
+	// Then
+	assert.NoError(t, err)
+	assert.Len(t, agg.GivePrimaryIDs(), 1)
+	assert.Equal(t, TestStruct2Name, agg.GiveSecondaryID().Name)
+	assert.NoError(t, container.Global.Validate())
+
+	cleanup()
+}
+
+func TestBindStructPointer(t *testing.T) {
+	// Given
+	setup()
+
+	container.MustBind[SecondaryIDGiver](NewTestStruct2)
+	err := container.BindStructInstance[*injectAggregator, injectAggregator](container.Global, container.WithLifetime(container.Transient))
+	assert.NoError(t, err)
+
+	// When
+	first, firstErr := container.Resolve[*injectAggregator]()
+	second, secondErr := container.Resolve[*injectAggregator]()
+
+	// Then
+	assert.NoError(t, firstErr)
+	assert.NoError(t, secondErr)
+	assert.NotSame(t, first, second)
+	assert.Same(t, first.Secondary, second.Secondary)
+
+	cleanup()
+}
+
+func TestBindStructOverride(t *testing.T) {
+	// Given
+	setup()
+
+	container.MustBind[SecondaryIDGiver](NewTestStruct2)
+	err := container.BindStruct[IDAggregator, injectAggregator]()
+	assert.NoError(t, err)
+	err = container.BindStruct[IDAggregator, injectAggregator]()
+	assert.NoError(t, err)
+
+	// When
+	all, err := container.ResolveAll[IDAggregator]()
+
+	// Then
+	assert.NoError(t, err)
+	assert.Len(t, all, 1)
+
+	cleanup()
+}
+
+func TestBindStructMissingDependency(t *testing.T) {
+	// Given
+	setup()
+
+	err := container.BindStruct[IDAggregator, injectAggregator]()
+	assert.NoError(t, err)
+
+	// When
+	agg, resolveErr := container.Resolve[IDAggregator]()
+	validateErr := container.Global.Validate()
+
+	// Then
+	assert.Nil(t, agg)
+	assert.ErrorIs(t, resolveErr, container.ErrNotBound)
+	assert.ErrorIs(t, validateErr, container.ErrNotBound)
+
+	cleanup()
+}
+
+func TestBindStructErrors(t *testing.T) {
+	// Given
+	setup()
+
+	type badFieldType struct {
+		Count int `inject:""`
+	}
+
+	// When & Then
+	assert.ErrorIs(t, container.BindStruct[IDAggregator, *injectAggregator](), container.ErrInvalidResolver)
+	assert.ErrorIs(t, container.BindStruct[PrimaryIDGiver, injectAggregator](), container.ErrInvalidResolver)
+	assert.ErrorIs(t, container.BindStruct[*badFieldType, badFieldType](), container.ErrInvalidResolver)
+
+	cleanup()
+}
+
+// Test types for struct injection
+type injectTarget struct {
+	Primaries   []PrimaryIDGiver `inject:""`
+	Secondary   SecondaryIDGiver `inject:""`
+	Optional    PrimaryIDGiver   `inject:"extra,optional"`
+	NotInjected string
+}
+
+type injectAggregator struct {
+	Primaries []PrimaryIDGiver `inject:""`
+	Secondary SecondaryIDGiver `inject:""`
+}
+
+var _ IDAggregator = &injectAggregator{}
+
+func (c *injectAggregator) GivePrimaryIDs() []ID {
+	retVal := make([]ID, len(c.Primaries))
+	for idx, val := range c.Primaries {
+		retVal[idx] = val.GivePrimaryID()
+	}
+	return retVal
+}
+
+func (c *injectAggregator) GiveSecondaryID() ID {
+	return c.Secondary.GiveSecondaryID()
+}
+
+func TestBindStructCycle(t *testing.T) {
+	// Given
+	setup()
+
+	container.MustBindStruct[*injectCycle, injectCycle]()
+
+	// When
+	resolved, resolveErr := container.Resolve[*injectCycle]()
+	validateErr := container.Global.Validate()
+
+	// Then
+	assert.Nil(t, resolved)
+	assert.ErrorIs(t, resolveErr, container.ErrDependencyCycle)
+	assert.ErrorIs(t, validateErr, container.ErrDependencyCycle)
+
+	cleanup()
+}
+
+type injectCycle struct {
+	Self *injectCycle `inject:""`
+}
@@ -0,0 +1,180 @@
+package container
+
+import (
+	"reflect"
+)
+
+// Describes a bound type and name along with everything bound to it
+type BindingInfo struct {
+	// The bound type
+	Type reflect.Type
+	// The name the bindings were bound with, empty if unnamed
+	Name string
+	// The resolvers bound to the type, in precedence order. The first is the
+	// one Resolve returns the concrete of.
+	Resolvers []ResolverInfo
+}
+
+// Describes a single resolver, value or struct bound to a bound type
+type ResolverInfo struct {
+	// The resolver function. Values and structs are bound with a generated
+	// resolver.
+	Resolver reflect.Value
+	// The resolver's function name, or a description of the value or struct
+	// that was bound
+	Function string
+	// Where the resolver function is defined, if known
+	File string
+	Line int
+	// The lifetime the resolver was bound with
+	Lifetime Lifetime
+	// The module that provided the resolver, empty if it was bound directly
+	Module string
+	// Whether only the module's own resolvers can depend on it
+	Private bool
+	// Whether the container has built and cached its concrete. Always true
+	// for values, and always false for transient and scoped resolvers since
+	// the container doesn't keep those.
+	Constructed bool
+	// What the resolver's arguments are resolved from, in argument order
+	Dependencies []DependencyInfo
+}
+
+// Describes what a resolver argument is resolved from
+type DependencyInfo struct {
+	// The bound type the argument is resolved from. For slice arguments, this
+	// is the element type.
+	Type reflect.Type
+	// The name of the binding the argument is resolved from, empty if unnamed
+	Name string
+	// Whether the argument is a slice that receives every concrete
+	All bool
+	// Whether the argument is left as its zero value when nothing is bound
+	Optional bool
+	// Whether the argument is a Lazy or func resolved when it's used, rather
+	// than when the resolver is called
+	Lazy bool
+	// Whether the argument is a context.Context that receives the context the
+	// resolve was started with rather than anything bound
+	Context bool
+}
+
+// Returns true if anything is bound to the provided type, without resolving
+// it. Uses the global container instance.
+func Has[T any]() bool {
+	return HasInstance[T](Global)
+}
+
+// Returns true if anything is bound to the provided type, without resolving
+// it. Uses the provided container instance.
+func HasInstance[T any](container *Container) bool {
+	return HasNamedInstance[T](container, "")
+}
+
+// Returns true if anything is bound to the provided type with the name,
+// without resolving it. Uses the global container instance.
+func HasNamed[T any](name string) bool {
+	return HasNamedInstance[T](Global, name)
+}
+
+// Returns true if anything is bound to the provided type with the name,
+// without resolving it. Uses the provided container instance.
+func HasNamedInstance[T any](container *Container, name string) bool {
+	_, resolvers := container.lookup(bindingKey{bindingType: getBindingType[T](), name: name})
+	visible, _ := visibleBindings(resolvers, nil)
+	return len(visible) > 0
+}
+
+// Lists every bound type in the container along with what's bound to it,
+// sorted by bound type and name. Bindings in parent containers aren't
+// included. Nothing is resolved.
+func (container *Container) Bindings() []BindingInfo {
+	container.lock.RLock()
+	defer container.lock.RUnlock()
+
+	keys := container.sortedBindingKeys()
+	infos := make([]BindingInfo, 0, len(keys))
+	for _, key := range keys {
+		infos = append(infos, container.bindingInfo(key, container.bindingToResolver[key]))
+	}
+	return infos
+}
+
+// Lists every bound type in the container that has a resolver depending on
+// the provided type, along with just those resolvers. Resolvers that depend on
+// it by name aren't included. Uses the global container instance.
+func Dependents[T any]() []BindingInfo {
+	return DependentsInstance[T](Global)
+}
+
+// Lists every bound type in the container that has a resolver depending on
+// the provided type, along with just those resolvers. Resolvers that depend on
+// it by name aren't included. Uses the provided container instance.
+func DependentsInstance[T any](container *Container) []BindingInfo {
+	target := bindingKey{bindingType: getBindingType[T]()}
+
+	container.lock.RLock()
+	defer container.lock.RUnlock()
+
+	var infos []BindingInfo
+	for _, key := range container.sortedBindingKeys() {
+		var dependents []*binding
+		for _, bound := range container.bindingToResolver[key] {
+			for i := 0; i < bound.resolver.Type().NumIn(); i++ {
+				if bound.argKey(i) == target {
+					dependents = append(dependents, bound)
+					break
+				}
+			}
+		}
+		if len(dependents) > 0 {
+			infos = append(infos, container.bindingInfo(key, dependents))
+		}
+	}
+	return infos
+}
+
+// Describes the bindings of a key, in precedence order. Expects the container
+// lock to be held.
+func (container *Container) bindingInfo(key bindingKey, bindings []*binding) BindingInfo {
+	info := BindingInfo{Type: key.bindingType, Name: key.name}
+	for i := len(bindings) - 1; i >= 0; i-- {
+		info.Resolvers = append(info.Resolvers, container.resolverInfo(bindings[i]))
+	}
+	return info
+}
+
+// Describes a single binding
+func (container *Container) resolverInfo(bound *binding) ResolverInfo {
+	info := ResolverInfo{
+		Resolver: bound.boundResolver(),
+		Lifetime: bound.lifetime,
+		Private:  bound.private,
+	}
+	info.Function, info.File, info.Line = bound.describe()
+	if bound.module != nil {
+		info.Module = bound.module.name
+	}
+
+	switch {
+	case bound.value.IsValid():
+		info.Constructed = true
+	case bound.lifetime == Singleton:
+		info.Constructed = container.instanceCache.has(bound)
+	}
+
+	resolverType := bound.resolver.Type()
+	for i := 0; i < resolverType.NumIn(); i++ {
+		argKey := bound.argKey(i)
+		info.Dependencies = append(info.Dependencies, DependencyInfo{
+			Type:     argKey.bindingType,
+			Name:     argKey.name,
+			All:      bound.argIsAll(i),
+			Optional: bound.argIsOptional(i),
+			Lazy:     bound.argIsDeferred(i),
+			Context:  bound.argIsContext(i),
+		})
+	}
+
+	return info
+}
@@ -0,0 +1,123 @@
+package container_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gobros/container"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHas(t *testing.T) {
+	// Given
+	setup()
+
+	container.MustBind[PrimaryIDGiver](NewTestStruct1)
+	container.MustBind[SecondaryIDGiver](NewTestStruct2, container.WithName("named"))
+
+	// When & Then
+	assert.True(t, container.Has[PrimaryIDGiver]())
+	assert.False(t, container.Has[SecondaryIDGiver]())
+	assert.True(t, container.HasNamed[SecondaryIDGiver]("named"))
+	assert.False(t, container.HasNamed[PrimaryIDGiver]("named"))
+	assert.Equal(t, 0, Str1InstanceNumber)
+
+	cleanup()
+}
+
+func TestHasInstanceHierarchyAndPrivate(t *testing.T) {
+	// Given
+	parent := &container.Container{}
+	container.MustBindInstance[PrimaryIDGiver](parent, NewTestStruct1)
+	container.MustInstallInstance(parent, container.NewModule("private",
+		container.Provide[SecondaryIDGiver](NewTestStruct2, container.Private()),
+	))
+	child := parent.NewChild()
+
+	// When & Then
+	assert.True(t, container.HasInstance[PrimaryIDGiver](child))
+	assert.False(t, container.HasInstance[SecondaryIDGiver](child))
+	assert.False(t, container.HasNamedInstance[PrimaryIDGiver](child, "named"))
+}
+
+func TestBindings(t *testing.T) {
+	// Given
+	c := &container.Container{}
+	container.MustBindInstance[PrimaryIDGiver](c, NewTestStruct1)
+	container.MustBindInstance[PrimaryIDGiver](c, NewTestStruct2, container.WithLifetime(container.Transient))
+	container.MustBindValueInstance[SecondaryIDGiver](c, &TestStruct2{})
+	container.MustBindInstance[IDAggregator](c, NewTestIDAggregatorStruct, container.WithArgName(1, "named"))
+	container.MustResolveInstance[PrimaryIDGiver](c)
+
+	// When
+	bindings := c.Bindings()
+
+	// Then
+	assert.Len(t, bindings, 3)
+
+	assert.Equal(t, reflect.TypeOf((*IDAggregator)(nil)).Elem(), bindings[0].Type)
+	aggregator := bindings[0].Resolvers[0]
+	assert.Equal(t, "github.com/gobros/container_test.NewTestIDAggregatorStruct", aggregator.Function)
+	assert.True(t, strings.HasSuffix(aggregator.File, "container_test.go"))
+	assert.False(t, aggregator.Constructed)
+	assert.Equal(t, []container.DependencyInfo{
+		{Type: reflect.TypeOf((*PrimaryIDGiver)(nil)).Elem(), All: true},
+		{Type: reflect.TypeOf((*SecondaryIDGiver)(nil)).Elem(), Name: "named"},
+	}, aggregator.Dependencies)
+
+	// Most recently bound first
+	primaries := bindings[1].Resolvers
+	assert.Len(t, primaries, 2)
+	assert.Equal(t, "github.com/gobros/container_test.NewTestStruct2", primaries[0].Function)
+	assert.Equal(t, container.Transient, primaries[0].Lifetime)
+	assert.False(t, primaries[0].Constructed)
+	assert.Equal(t, "github.com/gobros/container_test.NewTestStruct1", primaries[1].Function)
+	assert.True(t, primaries[1].Constructed)
+
+	assert.True(t, bindings[2].Resolvers[0].Constructed)
+	assert.Empty(t, bindings[2].Resolvers[0].Dependencies)
+}
+
+func TestBindingsModule(t *testing.T) {
+	// Given
+	c := &container.Container{}
+	container.MustInstallInstance(c, container.NewModule("ids",
+		container.Provide[PrimaryIDGiver](NewTestStruct1, container.Private()),
+	))
+
+	// When
+	bindings := c.Bindings()
+
+	// Then
+	assert.Len(t, bindings, 1)
+	assert.Equal(t, "ids", bindings[0].Resolvers[0].Module)
+	assert.True(t, bindings[0].Resolvers[0].Private)
+	assert.Empty(t, (&container.Container{}).Bindings())
+}
+
+func TestDependents(t *testing.T) {
+	// Given
+	setup()
+
+	container.MustBind[IDAggregator](NewTestIDAggregatorStruct)
+	container.MustBind[IDAggregator](NewTestIDAggregatorStruct, container.WithName("named"))
+	container.MustBind[PrimaryIDGiver](func(sec SecondaryIDGiver) *TestStruct1 {
+		return NewTestStruct1()
+	})
+	container.MustBind[PrimaryIDGiver](NewTestStruct1)
+	container.MustBind[SecondaryIDGiver](NewTestStruct2)
+
+	// When
+	dependents := container.Dependents[SecondaryIDGiver]()
+	none := container.DependentsInstance[IDAggregator](container.Global)
+
+	// Then
+	assert.Len(t, dependents, 3)
+	assert.Equal(t, "named", dependents[1].Name)
+	assert.Len(t, dependents[2].Resolvers, 1)
+	assert.Equal(t, reflect.TypeOf((*PrimaryIDGiver)(nil)).Elem(), dependents[2].Type)
+	assert.Empty(t, none)
+
+	cleanup()
+}
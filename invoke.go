@@ -0,0 +1,155 @@
+package container
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Calls a function with its arguments resolved from the container, the same
+// way a resolver's arguments are resolved. The function can return nothing, an
+// error, a value, or a value and an error. Returns the function's error, or an
+// error if its arguments couldn't be resolved. Any other result is discarded.
+// Uses the global container instance.
+func Invoke(function any) error {
+	return InvokeInstance(Global, function)
+}
+
+// Calls a function with its arguments resolved from the container, the same
+// way a resolver's arguments are resolved. The function can return nothing, an
+// error, a value, or a value and an error. Returns the function's error, or an
+// error if its arguments couldn't be resolved. Any other result is discarded.
+// Uses the provided container instance.
+func InvokeInstance(container *Container, function any) error {
+	_, err := invoke(container, nil, function, nil)
+	return err
+}
+
+// Calls a function with its arguments resolved from the container, the same
+// way a resolver's arguments are resolved. Scoped bindings are resolved
+// against the provided scope.
+func InvokeScope(scope *Scope, function any) error {
+	_, err := invoke(scope.container, scope, function, nil)
+	return err
+}
+
+// Calls a function with its arguments resolved from the container and returns
+// its value. The function must return a value assignable to T, optionally
+// followed by an error. Uses the global container instance.
+func InvokeResult[T any](function any) (T, error) {
+	return InvokeResultInstance[T](Global, function)
+}
+
+// Calls a function with its arguments resolved from the container and returns
+// its value. The function must return a value assignable to T, optionally
+// followed by an error. Uses the provided container instance.
+func InvokeResultInstance[T any](container *Container, function any) (T, error) {
+	return invokeTyped[T](container, nil, function)
+}
+
+// Calls a function with its arguments resolved from the container and returns
+// its value. The function must return a value assignable to T, optionally
+// followed by an error. Scoped bindings are resolved against the provided
+// scope.
+func InvokeResultScope[T any](scope *Scope, function any) (T, error) {
+	return invokeTyped[T](scope.container, scope, function)
+}
+
+// Shared logic for the InvokeResult functions
+func invokeTyped[T any](container *Container, scope *Scope, function any) (T, error) {
+	var result T
+	resultValue, err := invoke(container, scope, function, reflect.TypeOf(&result).Elem())
+	if err != nil {
+		return result, err
+	}
+
+	// A nil interface comes back invalid, leave it as the zero value
+	if resultValue.IsValid() {
+		reflect.ValueOf(&result).Elem().Set(resultValue)
+	}
+	return result, nil
+}
+
+// Resolves a function's arguments and calls it. Returns the function's value
+// result, which is invalid if it doesn't have one. If resultType isn't nil, the
+// function must return a value assignable to it.
+func invoke(container *Container, scope *Scope, function any, resultType reflect.Type) (reflect.Value, error) {
+	functionValue := reflect.ValueOf(function)
+	if err := validateInvoke(functionValue, resultType); err != nil {
+		return reflect.Value{}, err
+	}
+
+	// Invoked functions aren't bound, so the function's type stands in for the
+	// bound type in dependency errors
+	key := bindingKey{bindingType: functionValue.Type()}
+	bound := &binding{resolver: functionValue}
+	if hasParamStructs(functionValue.Type()) {
+		bound = newParamBinding(functionValue)
+		if err := bound.expandParamArgs(); err != nil {
+			return reflect.Value{}, err
+		}
+	}
+	args, err := resolveArguments(container, resolution{scope: scope}, bound, key)
+	if err != nil {
+		return reflect.Value{}, fmt.Errorf("invoke error, failed to resolve arguments of (%v): %w", functionValue.Type(), err)
+	}
+
+	results := bound.resolver.Call(args)
+
+	if len(results) > 0 && invokeReturnsError(functionValue.Type()) {
+		// Error types that can't be nil, such as structs, are always errors
+		if errValue := results[len(results)-1]; !isNilConcrete(errValue.Interface()) {
+			return reflect.Value{}, errValue.Interface().(error)
+		}
+		results = results[:len(results)-1]
+	}
+	if len(results) == 0 || (results[0].Kind() == reflect.Interface && results[0].IsNil()) {
+		return reflect.Value{}, nil
+	}
+
+	return results[0], nil
+}
+
+// Validates that a function can be invoked, and returns a value assignable to
+// resultType if it isn't nil
+func validateInvoke(functionValue reflect.Value, resultType reflect.Type) error {
+	if functionValue.Kind() != reflect.Func {
+		return fmt.Errorf("invoke error, function must be a function")
+	}
+	if functionValue.IsNil() {
+		return fmt.Errorf("invoke error, function must not be nil")
+	}
+
+	functionType := functionValue.Type()
+	if functionType.NumOut() > 2 {
+		return fmt.Errorf("invoke error, function must return at most a value and an error")
+	}
+	if functionType.NumOut() == 2 && !invokeReturnsError(functionType) {
+		return fmt.Errorf("invoke error, functions with two returns must return an error as the second return")
+	}
+	if resultType != nil {
+		if functionType.NumOut() == 0 || (functionType.NumOut() == 1 && invokeReturnsError(functionType)) {
+			return fmt.Errorf("invoke error, function must return a value")
+		}
+		if !functionType.Out(0).AssignableTo(resultType) {
+			return fmt.Errorf("invoke error, function must return a type assignable to (%v)", resultType)
+		}
+	}
+
+	for i := 0; i < functionType.NumIn(); i++ {
+		paramType := functionType.In(i)
+		if !isResolvableParam(paramType) && !isParamStruct(paramType) {
+			return fmt.Errorf("invoke error, function input parameters must all be of type pointer, interface, slice, Optional, Lazy, func() (T, error), or a parameter struct")
+		}
+	}
+	if err := validateParamStructs(functionType); err != nil {
+		return fmt.Errorf("invoke error, %w", err)
+	}
+
+	return nil
+}
+
+// Returns true if the function's last return is an error
+func invokeReturnsError(functionType reflect.Type) bool {
+	errorType := reflect.TypeOf((*error)(nil)).Elem()
+	return functionType.NumOut() > 0 && functionType.Out(functionType.NumOut()-1).Implements(errorType)
+}
@@ -0,0 +1,232 @@
+package container_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gobros/container"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInvoke(t *testing.T) {
+	// Given
+	setup()
+
+	container.MustBind[PrimaryIDGiver](NewTestStruct1)
+	container.MustBind[PrimaryIDGiver](NewTestStruct2)
+	var invokedPrims []PrimaryIDGiver
+	var invokedPrim PrimaryIDGiver
+
+	// When
+	err := container.Invoke(func(prims []PrimaryIDGiver, prim PrimaryIDGiver) {
+		invokedPrims = prims
+		invokedPrim = prim
+	})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Len(t, invokedPrims, 2)
+	assert.Same(t, invokedPrims[1], invokedPrim)
+	assert.Equal(t, TestStruct2Name, invokedPrim.GivePrimaryID().Name)
+
+	cleanup()
+}
+
+func TestInvokeSharesSingletons(t *testing.T) {
+	// Given
+	setup()
+
+	container.MustBind[PrimaryIDGiver](NewTestStruct1)
+	resolved := container.MustResolve[PrimaryIDGiver]()
+
+	// When
+	var invoked PrimaryIDGiver
+	err := container.Invoke(func(prim PrimaryIDGiver) error {
+		invoked = prim
+		return nil
+	})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Same(t, resolved, invoked)
+	assert.Equal(t, 1, Str1InstanceNumber)
+
+	cleanup()
+}
+
+func TestInvokeReturnsError(t *testing.T) {
+	// Given
+	setup()
+
+	invokeErr := errors.New("invoked function did a bad!")
+
+	// When
+	err := container.Invoke(func() (*TestStruct1, error) {
+		return nil, invokeErr
+	})
+
+	// Then
+	assert.Same(t, invokeErr, err)
+
+	cleanup()
+}
+
+// Test error type that isn't a pointer or interface
+type valueError struct {
+	message string
+}
+
+func (e valueError) Error() string {
+	return e.message
+}
+
+func TestInvokeReturnsValueError(t *testing.T) {
+	// Given
+	setup()
+
+	// When
+	err := container.Invoke(func() valueError {
+		return valueError{message: "invoked function did a bad!"}
+	})
+	_, resultErr := container.InvokeResult[ID](func() (ID, valueError) {
+		return ID{}, valueError{message: "invoked function did a bad!"}
+	})
+	nilErr := container.Invoke(func() *valueError {
+		return nil
+	})
+
+	// Then
+	assert.Equal(t, valueError{message: "invoked function did a bad!"}, err)
+	assert.Equal(t, valueError{message: "invoked function did a bad!"}, resultErr)
+	assert.NoError(t, nilErr)
+
+	cleanup()
+}
+
+func TestInvokeMissingDependency(t *testing.T) {
+	// Given
+	setup()
+
+	called := false
+
+	// When
+	err := container.Invoke(func(prim PrimaryIDGiver) {
+		called = true
+	})
+
+	// Then
+	assert.ErrorIs(t, err, container.ErrNotBound)
+	assert.Contains(t, err.Error(), "invoke error, failed to resolve arguments")
+	assert.False(t, called)
+
+	cleanup()
+}
+
+func TestInvokeInvalidFunction(t *testing.T) {
+	// Given
+	setup()
+
+	// When & Then
+	assert.Error(t, container.Invoke("not a function"))
+	assert.Error(t, container.Invoke((func())(nil)))
+	assert.Error(t, container.Invoke(func(id ID) {}))
+	assert.Error(t, container.Invoke(func() (int, int) { return 0, 0 }))
+	assert.Error(t, container.Invoke(func() (int, int, error) { return 0, 0, nil }))
+
+	cleanup()
+}
+
+func TestInvokeResult(t *testing.T) {
+	// Given
+	setup()
+
+	container.MustBind[PrimaryIDGiver](NewTestStruct1)
+
+	// When
+	id, err := container.InvokeResult[ID](func(prim PrimaryIDGiver) (ID, error) {
+		return prim.GivePrimaryID(), nil
+	})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, TestStruct1Name, id.Name)
+
+	cleanup()
+}
+
+func TestInvokeResultNilInterface(t *testing.T) {
+	// Given
+	setup()
+
+	// When
+	prim, err := container.InvokeResult[PrimaryIDGiver](func() PrimaryIDGiver {
+		return nil
+	})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Nil(t, prim)
+
+	cleanup()
+}
+
+func TestInvokeResultWrongType(t *testing.T) {
+	// Given
+	setup()
+
+	// When
+	_, noValueErr := container.InvokeResult[ID](func() error { return nil })
+	_, wrongTypeErr := container.InvokeResult[ID](func() *TestStruct1 { return nil })
+
+	// Then
+	assert.ErrorContains(t, noValueErr, "function must return a value")
+	assert.ErrorContains(t, wrongTypeErr, "function must return a type assignable to")
+
+	cleanup()
+}
+
+func TestInvokeScope(t *testing.T) {
+	// Given
+	setup()
+
+	container.MustBind[PrimaryIDGiver](NewTestStruct1, container.WithLifetime(container.Scoped))
+	scope := container.Global.NewScope()
+	resolved := container.MustResolveScope[PrimaryIDGiver](scope)
+
+	// When
+	invoked := container.MustInvokeResultScope[PrimaryIDGiver](scope, func(prim PrimaryIDGiver) PrimaryIDGiver {
+		return prim
+	})
+	unscopedErr := container.Invoke(func(prim PrimaryIDGiver) {})
+
+	// Then
+	assert.Same(t, resolved, invoked)
+	assert.ErrorIs(t, unscopedErr, container.ErrNoScope)
+	assert.NoError(t, scope.Close())
+
+	cleanup()
+}
+
+func TestMustInvoke(t *testing.T) {
+	// Given
+	setup()
+
+	c := &container.Container{}
+	container.MustBindInstance[PrimaryIDGiver](c, NewTestStruct1)
+
+	// When & Then
+	assert.NotPanics(t, func() {
+		container.MustInvokeInstance(c, func(prim PrimaryIDGiver) {})
+	})
+	assert.Equal(t, TestStruct1Name, container.MustInvokeResultInstance[ID](c, func(prim PrimaryIDGiver) ID {
+		return prim.GivePrimaryID()
+	}).Name)
+	assert.Panics(t, func() {
+		container.MustInvoke(func(prim PrimaryIDGiver) {})
+	})
+	assert.Panics(t, func() {
+		container.MustInvokeResult[ID](func() (ID, error) { return ID{}, errors.New("did a bad!") })
+	})
+
+	cleanup()
+}
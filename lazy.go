@@ -0,0 +1,132 @@
+package container
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// A resolver argument that isn't resolved until Get is first called, for
+// dependencies that are expensive and rarely used, or that refer back to the
+// resolver. The first successful Get is remembered, so T is resolved at most
+// once per Lazy. Taking a func() (T, error) argument instead resolves T every
+// time it's called, respecting its lifetime.
+//
+// Deferred arguments aren't followed when looking for dependency cycles, so
+// two bindings can depend on each other as long as one of them does so
+// through a Lazy or func. Calling Get from inside the resolver that received
+// it resolves T right away, so it fails the same way a cycle would if T
+// depends on anything still being resolved, even through other deferred
+// arguments.
+type Lazy[T any] struct {
+	lazy *lazyValue
+}
+
+// The shared state of a Lazy, so copies of it resolve only once between them
+type lazyValue struct {
+	resolve  func() (reflect.Value, error)
+	lock     sync.Mutex
+	resolved bool
+	value    reflect.Value
+}
+
+// Implemented by every Lazy type, so they can be recognised and created
+// without knowing T
+type lazyDependency interface {
+	// Returns T
+	dependencyType() reflect.Type
+	// Sets the func Get calls to resolve T
+	setResolve(resolve func() (reflect.Value, error))
+}
+
+var lazyDependencyType = reflect.TypeOf((*lazyDependency)(nil)).Elem()
+
+// Resolves T the first time it's called and returns it. Later calls return the
+// same concrete. Errors aren't remembered, so a failed Get can be retried.
+func (l Lazy[T]) Get() (T, error) {
+	if l.lazy == nil {
+		return *new(T), fmt.Errorf("lazy error, Lazy[%v] wasn't created by a container", reflect.TypeOf((*T)(nil)).Elem())
+	}
+
+	l.lazy.lock.Lock()
+	defer l.lazy.lock.Unlock()
+
+	if !l.lazy.resolved {
+		value, err := l.lazy.resolve()
+		if err != nil {
+			return *new(T), err
+		}
+		l.lazy.value, l.lazy.resolved = value, true
+	}
+
+	var resolved T
+	reflect.ValueOf(&resolved).Elem().Set(l.lazy.value)
+	return resolved, nil
+}
+
+// Resolves T the first time it's called and returns it, panicking if it can't
+// be resolved
+func (l Lazy[T]) MustGet() T {
+	if retVal, err := l.Get(); err != nil {
+		panic(err)
+	} else {
+		return retVal
+	}
+}
+
+func (l *Lazy[T]) dependencyType() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+func (l *Lazy[T]) setResolve(resolve func() (reflect.Value, error)) {
+	l.lazy = &lazyValue{resolve: resolve}
+}
+
+// Returns true if the argument type is resolved when it's used rather than
+// when the resolver is called, either a Lazy or a func() (T, error)
+func isDeferredType(argType reflect.Type) bool {
+	return isLazyType(argType) || isProviderType(argType)
+}
+
+// Returns true if the type is a Lazy
+func isLazyType(argType reflect.Type) bool {
+	return argType.Kind() == reflect.Struct && reflect.PointerTo(argType).Implements(lazyDependencyType)
+}
+
+// Returns true if the type is a func() (T, error)
+func isProviderType(argType reflect.Type) bool {
+	return argType.Kind() == reflect.Func &&
+		argType.NumIn() == 0 &&
+		argType.NumOut() == 2 &&
+		argType.Out(1) == reflect.TypeOf((*error)(nil)).Elem()
+}
+
+// Returns the type a deferred argument resolves, or the argument type itself
+// if it isn't deferred
+func deferredElem(argType reflect.Type) reflect.Type {
+	switch {
+	case isLazyType(argType):
+		return reflect.New(argType).Interface().(lazyDependency).dependencyType()
+	case isProviderType(argType):
+		return argType.Out(0)
+	}
+	return argType
+}
+
+// Creates the Lazy or func for a deferred argument that calls resolve to
+// resolve T
+func newDeferred(argType reflect.Type, resolve func() (reflect.Value, error)) reflect.Value {
+	if isLazyType(argType) {
+		lazy := reflect.New(argType)
+		lazy.Interface().(lazyDependency).setResolve(resolve)
+		return lazy.Elem()
+	}
+
+	return reflect.MakeFunc(argType, func([]reflect.Value) []reflect.Value {
+		value, err := resolve()
+		if err != nil {
+			return []reflect.Value{reflect.Zero(argType.Out(0)), reflect.ValueOf(&err).Elem()}
+		}
+		return []reflect.Value{value, reflect.Zero(argType.Out(1))}
+	})
+}
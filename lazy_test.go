@@ -0,0 +1,272 @@
+package container_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gobros/container"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLazyResolvesOnFirstGet(t *testing.T) {
+	// Given
+	setup()
+
+	container.MustBind[PrimaryIDGiver](NewTestStruct1, container.WithLifetime(container.Transient))
+	var lazy container.Lazy[PrimaryIDGiver]
+	container.MustBind[SecondaryIDGiver](func(prim container.Lazy[PrimaryIDGiver]) *TestStruct2 {
+		lazy = prim
+		return NewTestStruct2()
+	})
+	container.MustResolve[SecondaryIDGiver]()
+	assert.Equal(t, 0, Str1InstanceNumber)
+
+	// When
+	first, firstErr := lazy.Get()
+	second, secondErr := lazy.Get()
+
+	// Then
+	assert.NoError(t, firstErr)
+	assert.NoError(t, secondErr)
+	assert.Same(t, first, second)
+	assert.Equal(t, 1, Str1InstanceNumber)
+
+	cleanup()
+}
+
+func TestProviderResolvesEveryCall(t *testing.T) {
+	// Given
+	setup()
+
+	container.MustBind[PrimaryIDGiver](NewTestStruct1, container.WithLifetime(container.Transient))
+	container.MustBind[*TestStruct2](NewTestStruct2)
+	var providePrim func() (PrimaryIDGiver, error)
+	var provideStr2 func() (*TestStruct2, error)
+	container.MustBind[IDAggregator](func(prim func() (PrimaryIDGiver, error), str2 func() (*TestStruct2, error)) *TestIDAggregatorStruct {
+		providePrim, provideStr2 = prim, str2
+		return &TestIDAggregatorStruct{}
+	})
+	container.MustResolve[IDAggregator]()
+
+	// When
+	firstPrim, _ := providePrim()
+	secondPrim, _ := providePrim()
+	firstStr2, _ := provideStr2()
+	secondStr2, err := provideStr2()
+
+	// Then
+	assert.NoError(t, err)
+	assert.NotSame(t, firstPrim, secondPrim)
+	assert.Equal(t, 2, Str1InstanceNumber)
+	assert.Same(t, firstStr2, secondStr2)
+	assert.Equal(t, 1, Str2InstanceNumber)
+
+	cleanup()
+}
+
+func TestLazyMutualReferences(t *testing.T) {
+	// Given
+	setup()
+
+	container.MustBind[*lazyServiceA](func(b container.Lazy[*lazyServiceB]) *lazyServiceA {
+		return &lazyServiceA{b: b}
+	})
+	container.MustBind[*lazyServiceB](func(a *lazyServiceA) *lazyServiceB {
+		return &lazyServiceB{a: a}
+	})
+
+	// When
+	validateErr := container.Global.Validate()
+	b, resolveErr := container.Resolve[*lazyServiceB]()
+
+	// Then
+	assert.NoError(t, validateErr)
+	assert.NoError(t, resolveErr)
+	assert.Same(t, b, b.a.b.MustGet())
+
+	cleanup()
+}
+
+func TestLazyGetWhileConstructingCycle(t *testing.T) {
+	// Given
+	setup()
+
+	container.MustBind[*lazyServiceA](func(b container.Lazy[*lazyServiceB]) (*lazyServiceA, error) {
+		if _, err := b.Get(); err != nil {
+			return nil, err
+		}
+		return &lazyServiceA{b: b}, nil
+	})
+	container.MustBind[*lazyServiceB](func(a *lazyServiceA) *lazyServiceB {
+		return &lazyServiceB{a: a}
+	})
+
+	// When
+	_, err := container.Resolve[*lazyServiceA]()
+
+	// Then
+	assert.ErrorIs(t, err, container.ErrDependencyCycle)
+
+	cleanup()
+}
+
+func TestLazyGetWhileConstructingConcurrentCycle(t *testing.T) {
+	// Given
+	setup()
+
+	constructingA := make(chan struct{})
+	container.MustBind[*lazyServiceA](func(b container.Lazy[*lazyServiceB]) (*lazyServiceA, error) {
+		close(constructingA)
+		// Give the other goroutine time to start building B and wait on A
+		time.Sleep(20 * time.Millisecond)
+		if _, err := b.Get(); err != nil {
+			return nil, err
+		}
+		return &lazyServiceA{b: b}, nil
+	})
+	container.MustBind[*lazyServiceB](func(a *lazyServiceA) *lazyServiceB {
+		return &lazyServiceB{a: a}
+	})
+
+	// When
+	var aErr, bErr error
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		_, aErr = container.Resolve[*lazyServiceA]()
+	}()
+	<-constructingA
+	go func() {
+		defer wg.Done()
+		_, bErr = container.Resolve[*lazyServiceB]()
+	}()
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	// Then
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("resolves deadlocked")
+	}
+	assert.ErrorIs(t, aErr, container.ErrDependencyCycle)
+	assert.ErrorIs(t, bErr, container.ErrDependencyCycle)
+
+	cleanup()
+}
+
+func TestLazyMissingDependency(t *testing.T) {
+	// Given
+	setup()
+
+	var lazy container.Lazy[PrimaryIDGiver]
+	container.MustBind[SecondaryIDGiver](func(prim container.Lazy[PrimaryIDGiver]) *TestStruct2 {
+		lazy = prim
+		return NewTestStruct2()
+	})
+
+	// When
+	_, resolveErr := container.Resolve[SecondaryIDGiver]()
+	_, getErr := lazy.Get()
+	validateErr := container.Global.Validate()
+
+	// Then
+	assert.NoError(t, resolveErr)
+	assert.ErrorIs(t, getErr, container.ErrNotBound)
+	assert.ErrorIs(t, validateErr, container.ErrNotBound)
+
+	// Errors aren't remembered
+	container.MustBind[PrimaryIDGiver](NewTestStruct1)
+	prim, err := lazy.Get()
+	assert.NoError(t, err)
+	assert.NotNil(t, prim)
+
+	cleanup()
+}
+
+func TestLazyNotFromContainer(t *testing.T) {
+	// Given
+	var lazy container.Lazy[PrimaryIDGiver]
+
+	// When
+	_, err := lazy.Get()
+
+	// Then
+	assert.Error(t, err)
+	assert.Panics(t, func() { lazy.MustGet() })
+}
+
+func TestLazyInvalidElem(t *testing.T) {
+	// Given
+	setup()
+
+	// When & Then
+	assert.ErrorIs(t, container.Bind[SecondaryIDGiver](func(id container.Lazy[ID]) *TestStruct2 { return nil }), container.ErrInvalidResolver)
+	assert.ErrorIs(t, container.Bind[SecondaryIDGiver](func(id func() (ID, error)) *TestStruct2 { return nil }), container.ErrInvalidResolver)
+	assert.ErrorIs(t, container.Bind[SecondaryIDGiver](func(id func() PrimaryIDGiver) *TestStruct2 { return nil }), container.ErrInvalidResolver)
+
+	cleanup()
+}
+
+func TestLazySlice(t *testing.T) {
+	// Given
+	setup()
+
+	container.MustBind[PrimaryIDGiver](NewTestStruct1)
+	container.MustBind[PrimaryIDGiver](NewTestStruct2)
+
+	// When
+	prims, err := container.InvokeResult[[]PrimaryIDGiver](func(prims container.Lazy[[]PrimaryIDGiver]) ([]PrimaryIDGiver, error) {
+		return prims.Get()
+	})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Len(t, prims, 2)
+
+	cleanup()
+}
+
+func TestLazyIntrospection(t *testing.T) {
+	// Given
+	setup()
+
+	container.MustBind[SecondaryIDGiver](func(prim container.Lazy[PrimaryIDGiver], prims func() ([]PrimaryIDGiver, error)) *TestStruct2 {
+		return NewTestStruct2()
+	})
+
+	// When
+	deps := container.Global.Bindings()[0].Resolvers[0].Dependencies
+	graph := container.Global.Graph()
+
+	// Then
+	assert.Len(t, deps, 2)
+	assert.True(t, deps[0].Lazy)
+	assert.False(t, deps[0].All)
+	assert.True(t, deps[1].Lazy)
+	assert.True(t, deps[1].All)
+	lazyEdges := 0
+	for _, edge := range graph.Edges {
+		if edge.Lazy {
+			lazyEdges++
+		}
+	}
+	assert.Equal(t, 2, lazyEdges)
+	assert.Contains(t, graph.DOT(), "arg 1 (all, lazy)")
+
+	cleanup()
+}
+
+// Test types that refer to each other, one of them lazily
+type lazyServiceA struct {
+	b container.Lazy[*lazyServiceB]
+}
+
+type lazyServiceB struct {
+	a *lazyServiceA
+}
@@ -0,0 +1,302 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/signal"
+	"reflect"
+	"sync"
+	"syscall"
+)
+
+// Implemented by concretes that need to do work before the application starts,
+// such as opening connections or warming caches. Called by Container.Start.
+type Starter interface {
+	// Starts the concrete. Should give up and return once ctx is done.
+	OnStart(ctx context.Context) error
+}
+
+// Implemented by concretes that need to release resources when the container
+// or scope that built them is stopped or closed. Takes precedence over
+// io.Closer when a concrete implements both.
+type Stopper interface {
+	// Stops the concrete. Should give up and return once ctx is done.
+	OnStop(ctx context.Context) error
+}
+
+// Implemented by long-running services, such as servers or workers. Run is
+// called on its own goroutine once the container has started and should block
+// until ctx is cancelled, which happens when the container is stopped.
+type Runner interface {
+	Run(ctx context.Context) error
+}
+
+// Tracks whether a container has been started and what it started. Guards
+// the stopped flag of the container's concretes.
+type lifecycle struct {
+	lock    sync.Mutex
+	started bool
+	// Concretes that were built when the container was started, in the order
+	// they were built
+	participants []*builtConcrete
+	// The Runners started along with the container
+	runners *runGroup
+}
+
+// A group of Runners started together. The first one to fail signals the rest
+// of the application to stop.
+type runGroup struct {
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	// Closed when the first Runner fails
+	failed   chan struct{}
+	failOnce sync.Once
+	lock     sync.Mutex
+	errs     []error
+}
+
+// Registers a func to call with the concrete when the container or scope that
+// built it is closed. Called after the concrete is stopped, and in the order
+// they were registered if there are several. The resolver's return type must
+// be assignable to T. Can't be used with values or the Transient lifetime,
+// since the container doesn't keep track of those concretes.
+func WithCleanup[T any](cleanup func(T) error) BindOption {
+	return func(b *binding) {
+		b.cleanups = append(b.cleanups, func(instance any) error {
+			return cleanup(instance.(T))
+		})
+		b.cleanupTypes = append(b.cleanupTypes, getBindingType[T]())
+	}
+}
+
+// Starts every singleton the container has built, in the order they were
+// built in so concretes are started after the concretes they depend on.
+// Concretes that implement Starter have OnStart called. If one fails, the
+// concretes before it are stopped again in reverse order and the error is
+// returned. Once everything has started, concretes that implement Runner are
+// run on their own goroutines until the container is stopped. Only concretes
+// that have already been built take part, so resolve the application's entry
+// points before starting.
+func (container *Container) Start(ctx context.Context) error {
+	_, err := container.start(ctx)
+	return err
+}
+
+// Shared logic for Start and Run. Returns the Runners that were started.
+func (container *Container) start(ctx context.Context) (*runGroup, error) {
+	state := &container.lifecycle
+	state.lock.Lock()
+	defer state.lock.Unlock()
+
+	if state.started {
+		return nil, fmt.Errorf("failed to start container, %w", ErrAlreadyStarted)
+	}
+
+	participants := container.instanceCache.snapshot()
+	for idx, concrete := range participants {
+		if starter, ok := concrete.instance.(Starter); ok && !isNilConcrete(concrete.instance) {
+			if err := starter.OnStart(ctx); err != nil {
+				startErr := fmt.Errorf("failed to start concrete (%T): %w", concrete.instance, err)
+				return nil, errors.Join(startErr, stopParticipants(ctx, participants[:idx]))
+			}
+		}
+		// Stopped by an earlier Stop or rollback, but it's running again
+		concrete.stopped = false
+	}
+
+	// Runners outlive the start call, so they only stop when we stop them
+	runCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	runners := &runGroup{cancel: cancel, failed: make(chan struct{})}
+	for _, concrete := range participants {
+		runner, ok := concrete.instance.(Runner)
+		if !ok || isNilConcrete(concrete.instance) {
+			continue
+		}
+
+		runners.wg.Add(1)
+		go func(instance any) {
+			defer runners.wg.Done()
+			err := runner.Run(runCtx)
+			// Returning because we asked it to isn't a failure
+			if err != nil && !(runCtx.Err() != nil && errors.Is(err, context.Canceled)) {
+				runners.fail(fmt.Errorf("failed to run concrete (%T): %w", instance, err))
+			}
+		}(concrete.instance)
+	}
+
+	state.started = true
+	state.participants = participants
+	state.runners = runners
+
+	return runners, nil
+}
+
+// Stops a started container. Runners are cancelled and waited on, then
+// concretes that implement Stopper have OnStop called in the reverse order
+// they were started in. Errors returned by Runners and Stoppers are joined
+// together. Does nothing if the container isn't started.
+func (container *Container) Stop(ctx context.Context) error {
+	state := &container.lifecycle
+	state.lock.Lock()
+	defer state.lock.Unlock()
+
+	return container.stopLocked(ctx)
+}
+
+// Stops the container. Expects the lifecycle lock to be held.
+func (container *Container) stopLocked(ctx context.Context) error {
+	state := &container.lifecycle
+	if !state.started {
+		return nil
+	}
+	state.started = false
+
+	var errs []error
+
+	state.runners.cancel()
+	stoppedRunning := make(chan struct{})
+	go func() {
+		state.runners.wg.Wait()
+		close(stoppedRunning)
+	}()
+	select {
+	case <-stoppedRunning:
+	case <-ctx.Done():
+		errs = append(errs, fmt.Errorf("runners didn't stop in time: %w", ctx.Err()))
+	}
+	errs = append(errs, state.runners.errors()...)
+
+	errs = append(errs, stopParticipants(ctx, state.participants))
+	state.participants = nil
+	state.runners = nil
+
+	return errors.Join(errs...)
+}
+
+// Starts the container, then blocks until ctx is cancelled, the process
+// receives SIGINT or SIGTERM, or a Runner fails. The container is then
+// stopped and any errors encountered are returned. Stopping isn't bounded by
+// a deadline, use Start and Stop directly for more control.
+func (container *Container) Run(ctx context.Context) error {
+	ctx, stopSignals := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer stopSignals()
+
+	runners, err := container.start(ctx)
+	if err != nil {
+		return err
+	}
+
+	select {
+	case <-ctx.Done():
+	case <-runners.failed:
+	}
+
+	return container.Stop(context.WithoutCancel(ctx))
+}
+
+// Tears down every singleton the container has built, in the reverse order
+// they were built in so concretes are stopped before the concretes they
+// depend on. A started container is stopped first. Concretes that implement
+// Stopper have OnStop called, otherwise ones that implement io.Closer are
+// closed. Cleanup funcs registered with WithCleanup are called afterwards.
+// Stops early if ctx is done before everything has been torn down. Any errors
+// encountered are joined together. Bindings are kept, so singletons are built
+// again the next time they're resolved.
+func (container *Container) Close(ctx context.Context) error {
+	state := &container.lifecycle
+	state.lock.Lock()
+	defer state.lock.Unlock()
+
+	stopErr := container.stopLocked(ctx)
+	return errors.Join(stopErr, stopConcretes(ctx, container.instanceCache.reset()))
+}
+
+// Records a Runner failure and signals that the group should stop
+func (runners *runGroup) fail(err error) {
+	runners.lock.Lock()
+	runners.errs = append(runners.errs, err)
+	runners.lock.Unlock()
+
+	runners.failOnce.Do(func() {
+		close(runners.failed)
+	})
+}
+
+// Returns the errors Runners have failed with so far
+func (runners *runGroup) errors() []error {
+	runners.lock.Lock()
+	defer runners.lock.Unlock()
+
+	return append([]error(nil), runners.errs...)
+}
+
+// Calls OnStop on the concretes that implement Stopper and haven't been
+// stopped yet, in reverse order
+func stopParticipants(ctx context.Context, participants []*builtConcrete) error {
+	var errs []error
+	for i := len(participants) - 1; i >= 0; i-- {
+		if err := stopConcrete(ctx, participants[i]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Calls OnStop on the concrete if it implements Stopper and hasn't been
+// stopped yet
+func stopConcrete(ctx context.Context, concrete *builtConcrete) error {
+	stopper, ok := concrete.instance.(Stopper)
+	if !ok || concrete.stopped || isNilConcrete(concrete.instance) {
+		return nil
+	}
+	concrete.stopped = true
+
+	if err := stopper.OnStop(ctx); err != nil {
+		return fmt.Errorf("failed to stop concrete (%T): %w", concrete.instance, err)
+	}
+	return nil
+}
+
+// Tears down the concretes in the reverse order they were built in, returning
+// any errors encountered joined together
+func stopConcretes(ctx context.Context, built []*builtConcrete) error {
+	var errs []error
+	for i := len(built) - 1; i >= 0; i-- {
+		if err := ctx.Err(); err != nil {
+			errs = append(errs, fmt.Errorf("shutdown interrupted with (%v) concretes left to stop: %w", i+1, err))
+			break
+		}
+
+		concrete := built[i]
+		// Resolvers are allowed to return nil, there's nothing to stop
+		if isNilConcrete(concrete.instance) {
+			continue
+		}
+
+		if _, ok := concrete.instance.(Stopper); ok {
+			if err := stopConcrete(ctx, concrete); err != nil {
+				errs = append(errs, err)
+			}
+		} else if closer, ok := concrete.instance.(io.Closer); ok {
+			if err := closer.Close(); err != nil {
+				errs = append(errs, fmt.Errorf("failed to close concrete (%T): %w", concrete.instance, err))
+			}
+		}
+
+		for _, cleanup := range concrete.cleanups {
+			if err := cleanup(concrete.instance); err != nil {
+				errs = append(errs, fmt.Errorf("failed to clean up concrete (%T): %w", concrete.instance, err))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Returns true if a resolver returned nil, either untyped or as a nil pointer
+func isNilConcrete(instance any) bool {
+	instanceValue := reflect.ValueOf(instance)
+	return !instanceValue.IsValid() || (instanceValue.Kind() == reflect.Ptr && instanceValue.IsNil())
+}
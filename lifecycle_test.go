@@ -0,0 +1,477 @@
+package container_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/gobros/container"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCloseStopsInReverseOrder(t *testing.T) {
+	// Given
+	setup()
+
+	var stopped []string
+	err := container.Bind[*stoppableC](func() *stoppableC {
+		return &stoppableC{stopped: &stopped}
+	})
+	assert.NoError(t, err)
+	err = container.Bind[*closableA](func(c *stoppableC) *closableA {
+		return &closableA{closed: &stopped}
+	})
+	assert.NoError(t, err)
+	err = container.Bind[*closableB](func(a *closableA) *closableB {
+		return &closableB{closed: &stopped}
+	}, container.WithCleanup(func(b *closableB) error {
+		stopped = append(stopped, "b cleanup")
+		return nil
+	}))
+	assert.NoError(t, err)
+	_, err = container.Resolve[*closableB]()
+	assert.NoError(t, err)
+
+	// When
+	err = container.Global.Close(context.Background())
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"b", "b cleanup", "a", "c"}, stopped)
+
+	cleanup()
+}
+
+func TestCloseCleanupOnInterface(t *testing.T) {
+	// Given
+	setup()
+
+	var cleaned []PrimaryIDGiver
+	err := container.Bind[PrimaryIDGiver](NewTestStruct1, container.WithCleanup(func(prim PrimaryIDGiver) error {
+		cleaned = append(cleaned, prim)
+		return nil
+	}))
+	assert.NoError(t, err)
+	resolved, err := container.Resolve[PrimaryIDGiver]()
+	assert.NoError(t, err)
+
+	// When
+	err = container.Global.Close(context.Background())
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, []PrimaryIDGiver{resolved}, cleaned)
+
+	cleanup()
+}
+
+func TestCloseJoinsErrors(t *testing.T) {
+	// Given
+	setup()
+
+	var stopped []string
+	err := container.Bind[*stoppableC](func() *stoppableC {
+		return &stoppableC{stopped: &stopped, err: errors.New("c did a bad!")}
+	})
+	assert.NoError(t, err)
+	err = container.Bind[*closableA](func(c *stoppableC) *closableA {
+		return &closableA{closed: &stopped, err: errors.New("a did a bad!")}
+	}, container.WithCleanup(func(a *closableA) error {
+		return errors.New("a cleanup did a bad!")
+	}))
+	assert.NoError(t, err)
+	_, err = container.Resolve[*closableA]()
+	assert.NoError(t, err)
+
+	// When
+	err = container.Global.Close(context.Background())
+
+	// Then
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "c did a bad!")
+	assert.Contains(t, err.Error(), "a did a bad!")
+	assert.Contains(t, err.Error(), "a cleanup did a bad!")
+	assert.Equal(t, []string{"a", "c"}, stopped)
+
+	cleanup()
+}
+
+func TestCloseContextDone(t *testing.T) {
+	// Given
+	setup()
+
+	var stopped []string
+	err := container.Bind[*stoppableC](func() *stoppableC {
+		return &stoppableC{stopped: &stopped}
+	})
+	assert.NoError(t, err)
+	_, err = container.Resolve[*stoppableC]()
+	assert.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// When
+	err = container.Global.Close(ctx)
+
+	// Then
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Empty(t, stopped)
+
+	cleanup()
+}
+
+func TestCloseRebuildsAfterwards(t *testing.T) {
+	// Given
+	setup()
+
+	err := container.Bind[PrimaryIDGiver](NewTestStruct1)
+	assert.NoError(t, err)
+	first, err := container.Resolve[PrimaryIDGiver]()
+	assert.NoError(t, err)
+	assert.NoError(t, container.Global.Close(context.Background()))
+
+	// When
+	second, err := container.Resolve[PrimaryIDGiver]()
+
+	// Then
+	assert.NoError(t, err)
+	assert.NotSame(t, first, second)
+	assert.Equal(t, 2, Str1InstanceNumber)
+
+	cleanup()
+}
+
+func TestCloseSkipsUnbuiltAndNil(t *testing.T) {
+	// Given
+	setup()
+
+	var stopped []string
+	err := container.Bind[*stoppableC](func() *stoppableC {
+		return &stoppableC{stopped: &stopped}
+	})
+	assert.NoError(t, err)
+	err = container.Bind[*closableA](func() *closableA {
+		return nil
+	})
+	assert.NoError(t, err)
+	_, err = container.Resolve[*closableA]()
+	assert.NoError(t, err)
+
+	// When
+	err = container.Global.Close(context.Background())
+
+	// Then
+	assert.NoError(t, err)
+	assert.Empty(t, stopped)
+
+	cleanup()
+}
+
+func TestScopeCloseRunsCleanups(t *testing.T) {
+	// Given
+	setup()
+
+	var stopped []string
+	err := container.Bind[*stoppableC](func() *stoppableC {
+		return &stoppableC{stopped: &stopped}
+	}, container.WithLifetime(container.Scoped), container.WithCleanup(func(c *stoppableC) error {
+		stopped = append(stopped, "c cleanup")
+		return nil
+	}))
+	assert.NoError(t, err)
+	scope := container.Global.NewScope()
+	_, err = container.ResolveScope[*stoppableC](scope)
+	assert.NoError(t, err)
+
+	// When
+	scopeErr := scope.Close()
+	containerErr := container.Global.Close(context.Background())
+
+	// Then
+	assert.NoError(t, scopeErr)
+	assert.NoError(t, containerErr)
+	assert.Equal(t, []string{"c", "c cleanup"}, stopped)
+
+	cleanup()
+}
+
+func TestWithCleanupErrors(t *testing.T) {
+	// Given
+	setup()
+
+	cleanupPrimary := container.WithCleanup(func(prim PrimaryIDGiver) error { return nil })
+
+	// When & Then
+	assert.ErrorIs(t, container.BindValue[PrimaryIDGiver](&TestStruct1{}, cleanupPrimary), container.ErrInvalidResolver)
+	assert.ErrorIs(t, container.Bind[PrimaryIDGiver](NewTestStruct1, cleanupPrimary, container.WithLifetime(container.Transient)), container.ErrInvalidResolver)
+	assert.ErrorIs(t, container.Bind[IDAggregator](NewTestIDAggregatorStruct, cleanupPrimary), container.ErrInvalidResolver)
+
+	cleanup()
+}
+
+// Test type that records when it's stopped
+type stoppableC struct {
+	stopped *[]string
+	err     error
+}
+
+var _ container.Stopper = &stoppableC{}
+
+func (c *stoppableC) OnStop(ctx context.Context) error {
+	*c.stopped = append(*c.stopped, "c")
+	return c.err
+}
+
+func TestStartAndStopInOrder(t *testing.T) {
+	// Given
+	setup()
+
+	var events []string
+	bindComponents(t, &events, nil)
+
+	// When
+	startErr := container.Global.Start(context.Background())
+	stopErr := container.Global.Stop(context.Background())
+	closeErr := container.Global.Close(context.Background())
+
+	// Then
+	assert.NoError(t, startErr)
+	assert.NoError(t, stopErr)
+	assert.NoError(t, closeErr)
+	assert.Equal(t, []string{"start first", "start second", "stop second", "stop first"}, events)
+
+	cleanup()
+}
+
+func TestStartRollsBack(t *testing.T) {
+	// Given
+	setup()
+
+	var events []string
+	bindComponents(t, &events, errors.New("second did a bad!"))
+
+	// When
+	err := container.Global.Start(context.Background())
+
+	// Then
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "second did a bad!")
+	assert.Equal(t, []string{"start first", "start second", "stop first"}, events)
+
+	// Closing tears down what wasn't rolled back
+	assert.NoError(t, container.Global.Stop(context.Background()))
+	assert.NoError(t, container.Global.Close(context.Background()))
+	assert.Equal(t, []string{"start first", "start second", "stop first", "stop second"}, events)
+
+	cleanup()
+}
+
+func TestStartAgainAfterStop(t *testing.T) {
+	// Given
+	setup()
+
+	var events []string
+	bindComponents(t, &events, nil)
+
+	// When
+	firstStartErr := container.Global.Start(context.Background())
+	firstStopErr := container.Global.Stop(context.Background())
+	secondStartErr := container.Global.Start(context.Background())
+	secondStopErr := container.Global.Stop(context.Background())
+	closeErr := container.Global.Close(context.Background())
+
+	// Then
+	assert.NoError(t, firstStartErr)
+	assert.NoError(t, firstStopErr)
+	assert.NoError(t, secondStartErr)
+	assert.NoError(t, secondStopErr)
+	assert.NoError(t, closeErr)
+	assert.Equal(t, []string{
+		"start first", "start second", "stop second", "stop first",
+		"start first", "start second", "stop second", "stop first",
+	}, events)
+
+	cleanup()
+}
+
+func TestStartAgainAfterRollBack(t *testing.T) {
+	// Given
+	setup()
+
+	var events []string
+	bindComponents(t, &events, errors.New("second did a bad!"))
+	assert.Error(t, container.Global.Start(context.Background()))
+	container.MustResolve[*componentSecond]().startErr = nil
+
+	// When
+	startErr := container.Global.Start(context.Background())
+	closeErr := container.Global.Close(context.Background())
+
+	// Then
+	assert.NoError(t, startErr)
+	assert.NoError(t, closeErr)
+	assert.Equal(t, []string{
+		"start first", "start second", "stop first",
+		"start first", "start second", "stop second", "stop first",
+	}, events)
+
+	cleanup()
+}
+
+func TestStartTwice(t *testing.T) {
+	// Given
+	setup()
+	assert.NoError(t, container.Global.Start(context.Background()))
+
+	// When
+	err := container.Global.Start(context.Background())
+
+	// Then
+	assert.ErrorIs(t, err, container.ErrAlreadyStarted)
+	assert.NoError(t, container.Global.Stop(context.Background()))
+
+	cleanup()
+}
+
+func TestStopNotStarted(t *testing.T) {
+	// Given
+	c := &container.Container{}
+
+	// When
+	err := c.Stop(context.Background())
+
+	// Then
+	assert.NoError(t, err)
+}
+
+func TestRunnersRunUntilStopped(t *testing.T) {
+	// Given
+	setup()
+
+	runner := &runnerD{running: make(chan struct{})}
+	container.MustBind[*runnerD](func() *runnerD { return runner })
+	container.MustResolve[*runnerD]()
+	err := container.Global.Start(context.Background())
+	assert.NoError(t, err)
+	<-runner.running
+
+	// When
+	err = container.Global.Close(context.Background())
+
+	// Then
+	assert.NoError(t, err)
+	assert.True(t, runner.returned.Load())
+
+	cleanup()
+}
+
+func TestRunStopsWhenContextDone(t *testing.T) {
+	// Given
+	setup()
+
+	var events []string
+	bindComponents(t, &events, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	runner := &runnerE{run: func(runCtx context.Context) error {
+		cancel()
+		<-runCtx.Done()
+		return runCtx.Err()
+	}}
+	container.MustBind[*runnerE](func() *runnerE { return runner })
+	container.MustResolve[*runnerE]()
+
+	// When
+	err := container.Global.Run(ctx)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"start first", "start second", "stop second", "stop first"}, events)
+
+	cleanup()
+}
+
+func TestRunStopsWhenRunnerFails(t *testing.T) {
+	// Given
+	setup()
+
+	var events []string
+	bindComponents(t, &events, nil)
+	runner := &runnerE{run: func(runCtx context.Context) error {
+		return errors.New("runner did a bad!")
+	}}
+	container.MustBind[*runnerE](func() *runnerE { return runner })
+	container.MustResolve[*runnerE]()
+
+	// When
+	err := container.Global.Run(context.Background())
+
+	// Then
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "runner did a bad!")
+	assert.Equal(t, []string{"start first", "start second", "stop second", "stop first"}, events)
+
+	cleanup()
+}
+
+// Binds and builds two components where second depends on first. Second fails
+// to start with startErr.
+func bindComponents(t *testing.T, events *[]string, startErr error) {
+	err := container.Bind[*componentFirst](func() *componentFirst {
+		return &componentFirst{component{name: "first", events: events}}
+	})
+	assert.NoError(t, err)
+	err = container.Bind[*componentSecond](func(first *componentFirst) *componentSecond {
+		return &componentSecond{component{name: "second", events: events, startErr: startErr}}
+	})
+	assert.NoError(t, err)
+	_, err = container.Resolve[*componentSecond]()
+	assert.NoError(t, err)
+}
+
+// Test types that record when they're started and stopped
+type component struct {
+	name     string
+	events   *[]string
+	startErr error
+}
+
+func (c *component) OnStart(ctx context.Context) error {
+	*c.events = append(*c.events, "start "+c.name)
+	return c.startErr
+}
+
+func (c *component) OnStop(ctx context.Context) error {
+	*c.events = append(*c.events, "stop "+c.name)
+	return nil
+}
+
+type componentFirst struct{ component }
+
+type componentSecond struct{ component }
+
+var _ container.Starter = &componentFirst{}
+var _ container.Stopper = &componentFirst{}
+
+// Test types that run until they're stopped
+type runnerD struct {
+	running  chan struct{}
+	returned atomic.Bool
+}
+
+var _ container.Runner = &runnerD{}
+
+func (r *runnerD) Run(ctx context.Context) error {
+	close(r.running)
+	<-ctx.Done()
+	r.returned.Store(true)
+	return ctx.Err()
+}
+
+type runnerE struct {
+	run func(ctx context.Context) error
+}
+
+func (r *runnerE) Run(ctx context.Context) error {
+	return r.run(ctx)
+}
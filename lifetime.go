@@ -0,0 +1,44 @@
+package container
+
+import "fmt"
+
+// Controls how long a concrete built by a resolver is reused for
+type Lifetime int
+
+const (
+	// The resolver is called once and the concrete is reused for every resolve.
+	// This is the default lifetime.
+	Singleton Lifetime = iota
+	// The resolver is called again every time the bound type is resolved,
+	// including when it's injected as an argument to another resolver.
+	Transient
+	// The resolver is called once per Scope and the concrete is reused for
+	// every resolve within that scope. Can only be resolved through a Scope.
+	Scoped
+)
+
+// Returns a readable name for the lifetime
+func (l Lifetime) String() string {
+	switch l {
+	case Singleton:
+		return "Singleton"
+	case Transient:
+		return "Transient"
+	case Scoped:
+		return "Scoped"
+	default:
+		return fmt.Sprintf("Lifetime(%d)", int(l))
+	}
+}
+
+// Returns true if the lifetime is one the container knows how to handle
+func (l Lifetime) valid() bool {
+	return l == Singleton || l == Transient || l == Scoped
+}
+
+// Sets the lifetime of a binding. Bindings are Singleton unless told otherwise.
+func WithLifetime(lifetime Lifetime) BindOption {
+	return func(b *binding) {
+		b.lifetime = lifetime
+	}
+}
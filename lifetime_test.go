@@ -0,0 +1,155 @@
+package container_test
+
+import (
+	"testing"
+
+	"github.com/gobros/container"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransientResolve(t *testing.T) {
+	// Given
+	setup()
+
+	err := container.Bind[PrimaryIDGiver](NewTestStruct1, container.WithLifetime(container.Transient))
+	assert.NoError(t, err)
+
+	// When
+	first, firstErr := container.Resolve[PrimaryIDGiver]()
+	second, secondErr := container.Resolve[PrimaryIDGiver]()
+
+	// Then
+	assert.NoError(t, firstErr)
+	assert.NoError(t, secondErr)
+	assert.Equal(t, 2, Str1InstanceNumber)
+	assert.Equal(t, 1, first.GivePrimaryID().Number)
+	assert.Equal(t, 2, second.GivePrimaryID().Number)
+
+	cleanup()
+}
+
+func TestTransientResolveAll(t *testing.T) {
+	// Given
+	setup()
+
+	err := container.Bind[PrimaryIDGiver](NewTestStruct1, container.WithLifetime(container.Transient))
+	assert.NoError(t, err)
+	err = container.Bind[PrimaryIDGiver](NewTestStruct2)
+	assert.NoError(t, err)
+
+	// When
+	first, firstErr := container.ResolveAll[PrimaryIDGiver]()
+	second, secondErr := container.ResolveAll[PrimaryIDGiver]()
+
+	// Then
+	assert.NoError(t, firstErr)
+	assert.NoError(t, secondErr)
+	assert.Len(t, first, 2)
+	assert.Len(t, second, 2)
+	assert.Equal(t, 2, Str1InstanceNumber)
+	assert.Equal(t, 1, Str2InstanceNumber)
+	assert.Same(t, first[1], second[1])
+
+	cleanup()
+}
+
+func TestTransientResolverArgument(t *testing.T) {
+	// Given
+	setup()
+
+	err := container.Bind[SecondaryIDGiver](NewTestStruct1, container.WithLifetime(container.Transient))
+	assert.NoError(t, err)
+	err = container.Bind[IDAggregator](NewTestIDAggregatorStruct, container.WithLifetime(container.Transient))
+	assert.NoError(t, err)
+
+	// When
+	first, firstErr := container.Resolve[IDAggregator]()
+	second, secondErr := container.Resolve[IDAggregator]()
+
+	// Then
+	assert.NoError(t, firstErr)
+	assert.NoError(t, secondErr)
+	assert.Equal(t, 2, Str1InstanceNumber)
+	assert.Equal(t, 1, first.GiveSecondaryID().Number)
+	assert.Equal(t, 2, second.GiveSecondaryID().Number)
+
+	cleanup()
+}
+
+func TestSingletonArgumentOfTransient(t *testing.T) {
+	// Given
+	setup()
+
+	err := container.Bind[SecondaryIDGiver](NewTestStruct1)
+	assert.NoError(t, err)
+	err = container.Bind[IDAggregator](NewTestIDAggregatorStruct, container.WithLifetime(container.Transient))
+	assert.NoError(t, err)
+
+	// When
+	first, firstErr := container.Resolve[IDAggregator]()
+	second, secondErr := container.Resolve[IDAggregator]()
+
+	// Then
+	assert.NoError(t, firstErr)
+	assert.NoError(t, secondErr)
+	assert.NotSame(t, first, second)
+	assert.Equal(t, 1, Str1InstanceNumber)
+
+	cleanup()
+}
+
+func TestTransientAndSingletonShareResolver(t *testing.T) {
+	// Given
+	setup()
+
+	err := container.Bind[PrimaryIDGiver](NewTestStruct1, container.WithLifetime(container.Transient))
+	assert.NoError(t, err)
+	err = container.Bind[SecondaryIDGiver](NewTestStruct1)
+	assert.NoError(t, err)
+
+	// When
+	sec1 := container.MustResolve[SecondaryIDGiver]()
+	prim := container.MustResolve[PrimaryIDGiver]()
+	sec2 := container.MustResolve[SecondaryIDGiver]()
+
+	// Then
+	assert.Same(t, sec1, sec2)
+	assert.NotEqual(t, sec1.GiveSecondaryID().Number, prim.GivePrimaryID().Number)
+	assert.Equal(t, 2, Str1InstanceNumber)
+
+	cleanup()
+}
+
+func TestRebindChangesLifetime(t *testing.T) {
+	// Given
+	setup()
+
+	err := container.Bind[PrimaryIDGiver](NewTestStruct1, container.WithLifetime(container.Transient))
+	assert.NoError(t, err)
+	err = container.Bind[PrimaryIDGiver](NewTestStruct1, container.WithLifetime(container.Singleton))
+	assert.NoError(t, err)
+
+	// When
+	first := container.MustResolve[PrimaryIDGiver]()
+	second := container.MustResolve[PrimaryIDGiver]()
+
+	// Then
+	assert.Same(t, first, second)
+	assert.Equal(t, 1, Str1InstanceNumber)
+
+	cleanup()
+}
+
+func TestUnknownLifetime(t *testing.T) {
+	// Given
+	setup()
+
+	// When
+	err := container.Bind[PrimaryIDGiver](NewTestStruct1, container.WithLifetime(container.Lifetime(42)))
+
+	// Then
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Lifetime(42)")
+
+	cleanup()
+}
@@ -0,0 +1,210 @@
+package container
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// A named, reusable set of bindings, such as everything a library needs bound
+// to work. Modules can include other modules, and each module is only
+// installed into a container once no matter how many times it's installed or
+// included. Bindings marked Private can only be depended on by the module's
+// own resolvers.
+type Module struct {
+	name string
+	// Modules installed along with this one, before it
+	includes []*Module
+	// Bindings the module provides, in the order they were given
+	provides []moduleBinding
+}
+
+// A binding provided by a module. Created when the module is installed.
+type moduleBinding struct {
+	bindingType reflect.Type
+	build       func() (*binding, error)
+	opts        []BindOption
+}
+
+// Configures what a module provides. Passed to NewModule.
+type ModuleOption func(*Module)
+
+// Creates a module with the name. The name identifies the module, so
+// installing a different module with the same name into a container fails.
+func NewModule(name string, opts ...ModuleOption) *Module {
+	module := &Module{name: name}
+	for _, opt := range opts {
+		opt(module)
+	}
+	return module
+}
+
+// Returns the name the module was created with
+func (module *Module) Name() string {
+	return module.name
+}
+
+// Installs the modules along with this one. Included modules are installed
+// first.
+func Include(modules ...*Module) ModuleOption {
+	return func(module *Module) {
+		module.includes = append(module.includes, modules...)
+	}
+}
+
+// Provides a resolver for a bound type, the same way Bind does
+func Provide[T any](resolver any, opts ...BindOption) ModuleOption {
+	bindingType := getBindingType[T]()
+	return provide(bindingType, func() (*binding, error) {
+		return newResolverBinding(bindingType, resolver)
+	}, opts)
+}
+
+// Provides a value for a bound type, the same way BindValue does
+func ProvideValue[T any](value any, opts ...BindOption) ModuleOption {
+	bindingType := getBindingType[T]()
+	return provide(bindingType, func() (*binding, error) {
+		return newValueBinding(bindingType, value)
+	}, opts)
+}
+
+// Provides a struct type for a bound type, the same way BindStruct does
+func ProvideStruct[T any, S any](opts ...BindOption) ModuleOption {
+	bindingType := getBindingType[T]()
+	structType := getBindingType[S]()
+	return provide(bindingType, func() (*binding, error) {
+		return newStructBinding(bindingType, structType)
+	}, opts)
+}
+
+// Shared logic for the Provide functions
+func provide(bindingType reflect.Type, build func() (*binding, error), opts []BindOption) ModuleOption {
+	return func(module *Module) {
+		module.provides = append(module.provides, moduleBinding{bindingType: bindingType, build: build, opts: opts})
+	}
+}
+
+// Hides a binding provided by a module from everything but the module's own
+// resolvers. Resolving it from anywhere else behaves as if it wasn't bound.
+// Can only be used with the Provide functions.
+func Private() BindOption {
+	return func(b *binding) {
+		b.private = true
+	}
+}
+
+// Installs the modules and everything they include. Modules that were already
+// installed are skipped. Either every binding is added or, if any fail
+// validation, none are. Uses the global container instance.
+func Install(modules ...*Module) error {
+	return InstallInstance(Global, modules...)
+}
+
+// Installs the modules and everything they include. Modules that were already
+// installed are skipped. Either every binding is added or, if any fail
+// validation, none are. Uses the provided container instance.
+func InstallInstance(container *Container, modules ...*Module) error {
+	container.lock.Lock()
+	inserted, err := container.installLocked(modules)
+	container.lock.Unlock()
+
+	for _, provided := range inserted {
+		container.observeBind(provided.bindingType, provided.binding, provided.overridden)
+	}
+
+	return err
+}
+
+// A binding added by a module
+type installedBinding struct {
+	bindingType reflect.Type
+	binding     *binding
+	// Whether it replaced a binding from the same resolver or value
+	overridden bool
+}
+
+// Shared logic for InstallInstance. Returns the bindings that were added.
+// Expects the container lock to be held.
+func (container *Container) installLocked(modules []*Module) ([]installedBinding, error) {
+	var toInstall []*Module
+	seen := make(map[string]*Module)
+	for _, module := range modules {
+		if err := container.collectModules(module, seen, &toInstall); err != nil {
+			return nil, err
+		}
+	}
+
+	var prepared []installedBinding
+	var errs []error
+	for _, module := range toInstall {
+		for _, provided := range module.provides {
+			newBinding, err := provided.build()
+			if err == nil {
+				newBinding.module = module
+				err = prepareBinding(provided.bindingType, newBinding, provided.opts)
+			}
+			if err != nil {
+				errs = append(errs, fmt.Errorf("failed to install module (%v): %w", module.name, err))
+				continue
+			}
+			prepared = append(prepared, installedBinding{bindingType: provided.bindingType, binding: newBinding})
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	for idx, provided := range prepared {
+		prepared[idx].overridden = container.insertBinding(provided.bindingType, provided.binding)
+	}
+	if container.modules == nil {
+		container.modules = make(map[string]*Module)
+	}
+	for _, module := range toInstall {
+		container.modules[module.name] = module
+	}
+
+	return prepared, nil
+}
+
+// Adds the module and everything it includes to toInstall, included modules
+// first, skipping any already installed or seen. Expects the container lock
+// to be held.
+func (container *Container) collectModules(module *Module, seen map[string]*Module, toInstall *[]*Module) error {
+	for _, known := range []*Module{container.modules[module.name], seen[module.name]} {
+		if known == module {
+			return nil
+		}
+		if known != nil {
+			return fmt.Errorf("failed to install module (%v), a different module with the same name is already installed", module.name)
+		}
+	}
+	seen[module.name] = module
+
+	for _, included := range module.includes {
+		if err := container.collectModules(included, seen, toInstall); err != nil {
+			return err
+		}
+	}
+	*toInstall = append(*toInstall, module)
+
+	return nil
+}
+
+// Returns true if the binding can be depended on by the resolvers of the
+// module, which is nil outside of any module
+func (b *binding) visibleTo(module *Module) bool {
+	return !b.private || b.module == module
+}
+
+// Returns the bindings that can be depended on by the resolvers of the module,
+// and whether any were left out
+func visibleBindings(bindings []*binding, module *Module) ([]*binding, bool) {
+	visible := bindings[:0:0]
+	for _, bound := range bindings {
+		if bound.visibleTo(module) {
+			visible = append(visible, bound)
+		}
+	}
+	return visible, len(visible) != len(bindings)
+}
@@ -0,0 +1,183 @@
+package container_test
+
+import (
+	"testing"
+
+	"github.com/gobros/container"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInstallModule(t *testing.T) {
+	// Given
+	setup()
+
+	module := container.NewModule("ids",
+		container.Provide[PrimaryIDGiver](NewTestStruct1),
+		container.ProvideValue[SecondaryIDGiver](&TestStruct2{InstanceId: 42}),
+		container.ProvideStruct[IDAggregator, injectAggregator](),
+	)
+
+	// When
+	err := container.Install(module)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "ids", module.Name())
+	agg, err := container.Resolve[IDAggregator]()
+	assert.NoError(t, err)
+	assert.Len(t, agg.GivePrimaryIDs(), 1)
+	assert.Equal(t, 42, agg.GiveSecondaryID().Number)
+
+	cleanup()
+}
+
+func TestInstallModuleIncludes(t *testing.T) {
+	// Given
+	setup()
+
+	calls := 0
+	shared := container.NewModule("shared", container.Provide[PrimaryIDGiver](func() *TestStruct1 {
+		calls++
+		return NewTestStruct1()
+	}, container.WithLifetime(container.Transient)))
+	first := container.NewModule("first", container.Include(shared), container.Provide[SecondaryIDGiver](NewTestStruct2))
+	second := container.NewModule("second", container.Include(shared), container.Provide[IDAggregator](NewTestIDAggregatorStruct))
+
+	// When
+	err := container.Install(first, second)
+	assert.NoError(t, err)
+	err = container.Install(second)
+	assert.NoError(t, err)
+
+	// Then
+	all, err := container.ResolveAll[PrimaryIDGiver]()
+	assert.NoError(t, err)
+	assert.Len(t, all, 1)
+	assert.Equal(t, 1, calls)
+	assert.NoError(t, container.Global.Validate())
+
+	cleanup()
+}
+
+func TestInstallModuleNameConflict(t *testing.T) {
+	// Given
+	setup()
+
+	first := container.NewModule("ids", container.Provide[PrimaryIDGiver](NewTestStruct1))
+	second := container.NewModule("ids", container.Provide[SecondaryIDGiver](NewTestStruct2))
+	assert.NoError(t, container.Install(first))
+
+	// When
+	err := container.Install(second)
+
+	// Then
+	assert.Error(t, err)
+	_, err = container.Resolve[SecondaryIDGiver]()
+	assert.ErrorIs(t, err, container.ErrNotBound)
+
+	cleanup()
+}
+
+func TestInstallModuleAllOrNothing(t *testing.T) {
+	// Given
+	setup()
+
+	module := container.NewModule("broken",
+		container.Provide[PrimaryIDGiver](NewTestStruct1),
+		container.Provide[SecondaryIDGiver](5),
+	)
+
+	// When
+	err := container.Install(module)
+
+	// Then
+	assert.ErrorIs(t, err, container.ErrInvalidResolver)
+	assert.Contains(t, err.Error(), "module (broken)")
+	_, err = container.Resolve[PrimaryIDGiver]()
+	assert.ErrorIs(t, err, container.ErrNotBound)
+
+	// A fixed module with the same name can still be installed
+	fixed := container.NewModule("broken", container.Provide[PrimaryIDGiver](NewTestStruct1))
+	assert.NoError(t, container.Install(fixed))
+
+	cleanup()
+}
+
+func TestInstallModulePrivate(t *testing.T) {
+	// Given
+	setup()
+
+	module := container.NewModule("ids",
+		container.Provide[SecondaryIDGiver](NewTestStruct2, container.Private()),
+		container.Provide[IDAggregator](NewTestIDAggregatorStruct),
+	)
+	assert.NoError(t, container.Install(module))
+
+	// When
+	agg, aggErr := container.Resolve[IDAggregator]()
+	sec, secErr := container.Resolve[SecondaryIDGiver]()
+
+	// Then
+	assert.NoError(t, aggErr)
+	assert.Equal(t, TestStruct2Name, agg.GiveSecondaryID().Name)
+	assert.Nil(t, sec)
+	assert.ErrorIs(t, secErr, container.ErrNotBound)
+	assert.NoError(t, container.Global.Validate())
+
+	cleanup()
+}
+
+func TestInstallModulePrivateHiddenFromOthers(t *testing.T) {
+	// Given
+	setup()
+
+	internal := container.NewModule("internal",
+		container.Provide[SecondaryIDGiver](NewTestStruct2, container.Private()),
+		container.Provide[PrimaryIDGiver](NewTestStruct1, container.Private()),
+	)
+	consumer := container.NewModule("consumer",
+		container.Include(internal),
+		container.Provide[IDAggregator](NewTestIDAggregatorStruct),
+	)
+	assert.NoError(t, container.Install(consumer))
+
+	// When
+	agg, err := container.Resolve[IDAggregator]()
+	validateErr := container.Global.Validate()
+
+	// Then
+	assert.Nil(t, agg)
+	assert.ErrorIs(t, err, container.ErrNotBound)
+	assert.ErrorIs(t, validateErr, container.ErrNotBound)
+
+	cleanup()
+}
+
+func TestPrivateOutsideModule(t *testing.T) {
+	// Given
+	setup()
+
+	// When
+	err := container.Bind[PrimaryIDGiver](NewTestStruct1, container.Private())
+
+	// Then
+	assert.ErrorIs(t, err, container.ErrInvalidResolver)
+
+	cleanup()
+}
+
+func TestMustInstall(t *testing.T) {
+	// Given
+	setup()
+
+	module := container.NewModule("ids", container.Provide[PrimaryIDGiver](NewTestStruct1))
+	broken := container.NewModule("broken", container.Provide[PrimaryIDGiver](5))
+
+	// When & Then
+	assert.NotPanics(t, func() { container.MustInstall(module) })
+	assert.NotPanics(t, func() { container.MustInstallInstance(container.Global, module) })
+	assert.Panics(t, func() { container.MustInstall(broken) })
+	assert.Panics(t, func() { container.MustInstallInstance(container.Global, broken) })
+
+	cleanup()
+}
@@ -1,18 +1,20 @@
 package container
 
+import "context"
+
 // Binds a resolver to a bound type. Can later be resolved for use. Uses the
 // global container instance.
-func MustBind[T any](resolver any) {
-	if err := Bind[T](resolver); err != nil {
-		panic(err.Error())
+func MustBind[T any](resolver any, opts ...BindOption) {
+	if err := Bind[T](resolver, opts...); err != nil {
+		panic(err)
 	}
 }
 
 // Binds a resolver to a bound type. Can later be resolved for use. Uses the
 // provided container instance.
-func MustBindInstance[T any](container *Container, resolver any) {
-	if err := BindInstance[T](container, resolver); err != nil {
-		panic(err.Error())
+func MustBindInstance[T any](container *Container, resolver any, opts ...BindOption) {
+	if err := BindInstance[T](container, resolver, opts...); err != nil {
+		panic(err)
 	}
 }
 
@@ -20,7 +22,7 @@ func MustBindInstance[T any](container *Container, resolver any) {
 // a slice. Uses the global container instance.
 func MustResolveAll[T any]() []T {
 	if retVal, err := ResolveAll[T](); err != nil {
-		panic(err.Error())
+		panic(err)
 	} else {
 		return retVal
 	}
@@ -30,7 +32,7 @@ func MustResolveAll[T any]() []T {
 // a slice. Uses the provided container instance.
 func MustResolveAllInstance[T any](container *Container) []T {
 	if retVal, err := ResolveAllInstance[T](container); err != nil {
-		panic(err.Error())
+		panic(err)
 	} else {
 		return retVal
 	}
@@ -41,7 +43,7 @@ func MustResolveAllInstance[T any](container *Container) []T {
 // global container instance.
 func MustResolve[T any]() T {
 	if retVal, err := Resolve[T](); err != nil {
-		panic(err.Error())
+		panic(err)
 	} else {
 		return retVal
 	}
@@ -52,8 +54,305 @@ func MustResolve[T any]() T {
 // provided container instance.
 func MustResolveInstance[T any](container *Container) T {
 	if retVal, err := ResolveInstance[T](container); err != nil {
-		panic(err.Error())
+		panic(err)
+	} else {
+		return retVal
+	}
+}
+
+// Attempts to resolve and return all concretes bound to the provided type as
+// a slice. Scoped bindings are resolved against the provided scope.
+func MustResolveAllScope[T any](scope *Scope) []T {
+	if retVal, err := ResolveAllScope[T](scope); err != nil {
+		panic(err)
+	} else {
+		return retVal
+	}
+}
+
+// Resolves a single concrete bound to the provided type. If multiple resolvers
+// were bound, the concrete from the most recent one is returned. Scoped
+// bindings are resolved against the provided scope.
+func MustResolveScope[T any](scope *Scope) T {
+	if retVal, err := ResolveScope[T](scope); err != nil {
+		panic(err)
+	} else {
+		return retVal
+	}
+}
+
+// Binds an existing value to a bound type. The value is returned as is every
+// time the bound type is resolved. Uses the global container instance.
+func MustBindValue[T any](value any, opts ...BindOption) {
+	if err := BindValue[T](value, opts...); err != nil {
+		panic(err)
+	}
+}
+
+// Binds an existing value to a bound type. The value is returned as is every
+// time the bound type is resolved. Uses the provided container instance.
+func MustBindValueInstance[T any](container *Container, value any, opts ...BindOption) {
+	if err := BindValueInstance[T](container, value, opts...); err != nil {
+		panic(err)
+	}
+}
+
+// Attempts to resolve and return all concretes bound to the provided type
+// with the name as a slice. Uses the global container instance.
+func MustResolveAllNamed[T any](name string) []T {
+	if retVal, err := ResolveAllNamed[T](name); err != nil {
+		panic(err)
+	} else {
+		return retVal
+	}
+}
+
+// Attempts to resolve and return all concretes bound to the provided type
+// with the name as a slice. Uses the provided container instance.
+func MustResolveAllNamedInstance[T any](container *Container, name string) []T {
+	if retVal, err := ResolveAllNamedInstance[T](container, name); err != nil {
+		panic(err)
+	} else {
+		return retVal
+	}
+}
+
+// Attempts to resolve and return all concretes bound to the provided type
+// with the name as a slice. Scoped bindings are resolved against the provided
+// scope.
+func MustResolveAllNamedScope[T any](scope *Scope, name string) []T {
+	if retVal, err := ResolveAllNamedScope[T](scope, name); err != nil {
+		panic(err)
+	} else {
+		return retVal
+	}
+}
+
+// Resolves a single concrete bound to the provided type with the name. If
+// multiple resolvers were bound, the concrete from the most recent one is
+// returned. Uses the global container instance.
+func MustResolveNamed[T any](name string) T {
+	if retVal, err := ResolveNamed[T](name); err != nil {
+		panic(err)
+	} else {
+		return retVal
+	}
+}
+
+// Resolves a single concrete bound to the provided type with the name. If
+// multiple resolvers were bound, the concrete from the most recent one is
+// returned. Uses the provided container instance.
+func MustResolveNamedInstance[T any](container *Container, name string) T {
+	if retVal, err := ResolveNamedInstance[T](container, name); err != nil {
+		panic(err)
+	} else {
+		return retVal
+	}
+}
+
+// Resolves a single concrete bound to the provided type with the name. If
+// multiple resolvers were bound, the concrete from the most recent one is
+// returned. Scoped bindings are resolved against the provided scope.
+func MustResolveNamedScope[T any](scope *Scope, name string) T {
+	if retVal, err := ResolveNamedScope[T](scope, name); err != nil {
+		panic(err)
+	} else {
+		return retVal
+	}
+}
+
+// Populates the tagged fields of a pointer to a struct from the container.
+// Uses the global container instance.
+func MustInject(target any) {
+	if err := Inject(target); err != nil {
+		panic(err)
+	}
+}
+
+// Populates the tagged fields of a pointer to a struct from the container.
+// Uses the provided container instance.
+func MustInjectInstance(container *Container, target any) {
+	if err := InjectInstance(container, target); err != nil {
+		panic(err)
+	}
+}
+
+// Binds a struct type to a bound type without a hand-written resolver. Uses
+// the global container instance.
+func MustBindStruct[T any, S any](opts ...BindOption) {
+	if err := BindStruct[T, S](opts...); err != nil {
+		panic(err)
+	}
+}
+
+// Binds a struct type to a bound type without a hand-written resolver. Uses
+// the provided container instance.
+func MustBindStructInstance[T any, S any](container *Container, opts ...BindOption) {
+	if err := BindStructInstance[T, S](container, opts...); err != nil {
+		panic(err)
+	}
+}
+
+// Attempts to resolve and return all concretes bound to the provided type in
+// the container and every one of its parents as a slice. Uses the provided
+// container instance.
+func MustResolveAllHierarchyInstance[T any](container *Container) []T {
+	if retVal, err := ResolveAllHierarchyInstance[T](container); err != nil {
+		panic(err)
+	} else {
+		return retVal
+	}
+}
+
+// Installs the modules and everything they include. Uses the global container
+// instance.
+func MustInstall(modules ...*Module) {
+	if err := Install(modules...); err != nil {
+		panic(err)
+	}
+}
+
+// Installs the modules and everything they include. Uses the provided
+// container instance.
+func MustInstallInstance(container *Container, modules ...*Module) {
+	if err := InstallInstance(container, modules...); err != nil {
+		panic(err)
+	}
+}
+
+// Calls a function with its arguments resolved from the container. Uses the
+// global container instance.
+func MustInvoke(function any) {
+	if err := Invoke(function); err != nil {
+		panic(err)
+	}
+}
+
+// Calls a function with its arguments resolved from the container. Uses the
+// provided container instance.
+func MustInvokeInstance(container *Container, function any) {
+	if err := InvokeInstance(container, function); err != nil {
+		panic(err)
+	}
+}
+
+// Calls a function with its arguments resolved from the container. Scoped
+// bindings are resolved against the provided scope.
+func MustInvokeScope(scope *Scope, function any) {
+	if err := InvokeScope(scope, function); err != nil {
+		panic(err)
+	}
+}
+
+// Calls a function with its arguments resolved from the container and returns
+// its value. Uses the global container instance.
+func MustInvokeResult[T any](function any) T {
+	if retVal, err := InvokeResult[T](function); err != nil {
+		panic(err)
+	} else {
+		return retVal
+	}
+}
+
+// Calls a function with its arguments resolved from the container and returns
+// its value. Uses the provided container instance.
+func MustInvokeResultInstance[T any](container *Container, function any) T {
+	if retVal, err := InvokeResultInstance[T](container, function); err != nil {
+		panic(err)
+	} else {
+		return retVal
+	}
+}
+
+// Calls a function with its arguments resolved from the container and returns
+// its value. Scoped bindings are resolved against the provided scope.
+func MustInvokeResultScope[T any](scope *Scope, function any) T {
+	if retVal, err := InvokeResultScope[T](scope, function); err != nil {
+		panic(err)
+	} else {
+		return retVal
+	}
+}
+
+// Attempts to resolve and return all concretes bound to the provided type as
+// a slice, passing ctx to resolvers. Uses the global container instance.
+func MustResolveAllContext[T any](ctx context.Context) []T {
+	if retVal, err := ResolveAllContext[T](ctx); err != nil {
+		panic(err)
 	} else {
 		return retVal
 	}
 }
+
+// Attempts to resolve and return all concretes bound to the provided type as
+// a slice, passing ctx to resolvers. Uses the provided container instance.
+func MustResolveAllInstanceContext[T any](ctx context.Context, container *Container) []T {
+	if retVal, err := ResolveAllInstanceContext[T](ctx, container); err != nil {
+		panic(err)
+	} else {
+		return retVal
+	}
+}
+
+// Attempts to resolve and return all concretes bound to the provided type as
+// a slice, passing ctx to resolvers. Scoped bindings are resolved against the
+// provided scope.
+func MustResolveAllScopeContext[T any](ctx context.Context, scope *Scope) []T {
+	if retVal, err := ResolveAllScopeContext[T](ctx, scope); err != nil {
+		panic(err)
+	} else {
+		return retVal
+	}
+}
+
+// Resolves a single concrete bound to the provided type, passing ctx to
+// resolvers. If multiple resolvers were bound, the concrete from the most
+// recent one is returned. Uses the global container instance.
+func MustResolveContext[T any](ctx context.Context) T {
+	if retVal, err := ResolveContext[T](ctx); err != nil {
+		panic(err)
+	} else {
+		return retVal
+	}
+}
+
+// Resolves a single concrete bound to the provided type, passing ctx to
+// resolvers. If multiple resolvers were bound, the concrete from the most
+// recent one is returned. Uses the provided container instance.
+func MustResolveInstanceContext[T any](ctx context.Context, container *Container) T {
+	if retVal, err := ResolveInstanceContext[T](ctx, container); err != nil {
+		panic(err)
+	} else {
+		return retVal
+	}
+}
+
+// Resolves a single concrete bound to the provided type, passing ctx to
+// resolvers. If multiple resolvers were bound, the concrete from the most
+// recent one is returned. Scoped bindings are resolved against the provided
+// scope.
+func MustResolveScopeContext[T any](ctx context.Context, scope *Scope) T {
+	if retVal, err := ResolveScopeContext[T](ctx, scope); err != nil {
+		panic(err)
+	} else {
+		return retVal
+	}
+}
+
+// Binds each field of the results struct returned by the resolver to the
+// field's type. The resolver is called once for all of them. Uses the global
+// container instance.
+func MustBindResults(resolver any, opts ...BindOption) {
+	if err := BindResults(resolver, opts...); err != nil {
+		panic(err)
+	}
+}
+
+// Binds each field of the results struct returned by the resolver to the
+// field's type. The resolver is called once for all of them. Uses the
+// provided container instance.
+func MustBindResultsInstance(container *Container, resolver any, opts ...BindOption) {
+	if err := BindResultsInstance(container, resolver, opts...); err != nil {
+		panic(err)
+	}
+}
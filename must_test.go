@@ -417,3 +417,200 @@ func TestMustResolveInstancePanic(t *testing.T) {
 
 	cleanup()
 }
+
+func TestMustBindTransient(t *testing.T) {
+	// Given
+	setup()
+
+	container.MustBind[PrimaryIDGiver](NewTestStruct1, container.WithLifetime(container.Transient))
+
+	// When
+	first := container.MustResolve[PrimaryIDGiver]()
+	second := container.MustResolve[PrimaryIDGiver]()
+
+	// Then
+	assert.NotSame(t, first, second)
+	assert.Equal(t, 2, Str1InstanceNumber)
+
+	cleanup()
+}
+
+func TestMustBindInstanceTransient(t *testing.T) {
+	// Given
+	setup()
+
+	container.MustBindInstance[PrimaryIDGiver](container.Global, NewTestStruct1, container.WithLifetime(container.Transient))
+
+	// When
+	all := container.MustResolveAllInstance[PrimaryIDGiver](container.Global)
+	single := container.MustResolveInstance[PrimaryIDGiver](container.Global)
+
+	// Then
+	assert.Len(t, all, 1)
+	assert.NotSame(t, all[0], single)
+	assert.Equal(t, 2, Str1InstanceNumber)
+
+	cleanup()
+}
+
+func TestMustBindUnknownLifetimePanic(t *testing.T) {
+	// Given
+	setup()
+
+	// When & Then
+	assert.Panics(t, func() {
+		container.MustBind[PrimaryIDGiver](NewTestStruct1, container.WithLifetime(container.Lifetime(-1)))
+	})
+
+	cleanup()
+}
+
+func TestMustResolveScopeHappy(t *testing.T) {
+	// Given
+	setup()
+
+	container.MustBind[PrimaryIDGiver](NewTestStruct1, container.WithLifetime(container.Scoped))
+	scope := container.Global.NewScope()
+
+	// When
+	single := container.MustResolveScope[PrimaryIDGiver](scope)
+	all := container.MustResolveAllScope[PrimaryIDGiver](scope)
+
+	// Then
+	assert.Len(t, all, 1)
+	assert.Same(t, single, all[0])
+	assert.Equal(t, 1, Str1InstanceNumber)
+
+	cleanup()
+}
+
+func TestMustResolveScopePanic(t *testing.T) {
+	// Given
+	setup()
+
+	scope := container.Global.NewScope()
+
+	// When & Then
+	assert.Panics(t, func() { container.MustResolveScope[PrimaryIDGiver](scope) })
+	assert.Panics(t, func() { container.MustResolveAllScope[PrimaryIDGiver](scope) })
+
+	cleanup()
+}
+
+func TestMustBindValueHappy(t *testing.T) {
+	// Given
+	setup()
+
+	value := &TestStruct1{InstanceId: 42}
+	container.MustBindValue[PrimaryIDGiver](value)
+	container.MustBindValueInstance[SecondaryIDGiver](container.Global, value)
+
+	// When
+	prim := container.MustResolve[PrimaryIDGiver]()
+	sec := container.MustResolve[SecondaryIDGiver]()
+
+	// Then
+	assert.Same(t, value, prim)
+	assert.Same(t, value, sec)
+
+	cleanup()
+}
+
+func TestMustBindValuePanic(t *testing.T) {
+	// Given
+	setup()
+
+	// When & Then
+	assert.Panics(t, func() { container.MustBindValue[PrimaryIDGiver](5) })
+	assert.Panics(t, func() { container.MustBindValueInstance[PrimaryIDGiver](container.Global, nil) })
+
+	cleanup()
+}
+
+func TestMustResolveNamedHappy(t *testing.T) {
+	// Given
+	setup()
+
+	container.MustBind[PrimaryIDGiver](NewTestStruct1, container.WithName("primary"))
+	container.MustBind[PrimaryIDGiver](NewTestStruct2, container.WithName("primary"), container.WithLifetime(container.Scoped))
+	scope := container.Global.NewScope()
+
+	// When & Then
+	assert.Len(t, container.MustResolveAllNamedScope[PrimaryIDGiver](scope, "primary"), 2)
+	assert.Equal(t, TestStruct2Name, container.MustResolveNamedScope[PrimaryIDGiver](scope, "primary").GivePrimaryID().Name)
+	assert.Panics(t, func() { container.MustResolveNamed[PrimaryIDGiver]("primary") })
+	assert.Panics(t, func() { container.MustResolveAllNamed[PrimaryIDGiver]("primary") })
+
+	cleanup()
+}
+
+func TestMustResolveNamedInstanceHappy(t *testing.T) {
+	// Given
+	setup()
+
+	container.MustBindInstance[PrimaryIDGiver](container.Global, NewTestStruct1, container.WithName("primary"))
+
+	// When
+	single := container.MustResolveNamedInstance[PrimaryIDGiver](container.Global, "primary")
+	all := container.MustResolveAllNamedInstance[PrimaryIDGiver](container.Global, "primary")
+	global := container.MustResolveNamed[PrimaryIDGiver]("primary")
+	globalAll := container.MustResolveAllNamed[PrimaryIDGiver]("primary")
+
+	// Then
+	assert.Same(t, single, all[0])
+	assert.Same(t, single, global)
+	assert.Same(t, single, globalAll[0])
+
+	cleanup()
+}
+
+func TestMustResolveNamedPanic(t *testing.T) {
+	// Given
+	setup()
+
+	scope := container.Global.NewScope()
+
+	// When & Then
+	assert.Panics(t, func() { container.MustResolveNamedInstance[PrimaryIDGiver](container.Global, "primary") })
+	assert.Panics(t, func() { container.MustResolveAllNamedInstance[PrimaryIDGiver](container.Global, "primary") })
+	assert.Panics(t, func() { container.MustResolveNamedScope[PrimaryIDGiver](scope, "primary") })
+	assert.Panics(t, func() { container.MustResolveAllNamedScope[PrimaryIDGiver](scope, "primary") })
+
+	cleanup()
+}
+
+func TestMustInjectHappy(t *testing.T) {
+	// Given
+	setup()
+
+	container.MustBind[SecondaryIDGiver](NewTestStruct2)
+	container.MustBindStruct[IDAggregator, injectAggregator]()
+	container.MustBindStructInstance[*injectAggregator, injectAggregator](container.Global)
+	target := &injectTarget{}
+	instanceTarget := &injectTarget{}
+
+	// When
+	container.MustInject(target)
+	container.MustInjectInstance(container.Global, instanceTarget)
+
+	// Then
+	assert.NotNil(t, target.Secondary)
+	assert.Same(t, target.Secondary, instanceTarget.Secondary)
+	assert.NotNil(t, container.MustResolve[IDAggregator]())
+	assert.NotNil(t, container.MustResolve[*injectAggregator]())
+
+	cleanup()
+}
+
+func TestMustInjectPanic(t *testing.T) {
+	// Given
+	setup()
+
+	// When & Then
+	assert.Panics(t, func() { container.MustInject(&injectTarget{}) })
+	assert.Panics(t, func() { container.MustInjectInstance(container.Global, &injectTarget{}) })
+	assert.Panics(t, func() { container.MustBindStruct[PrimaryIDGiver, injectAggregator]() })
+	assert.Panics(t, func() { container.MustBindStructInstance[PrimaryIDGiver, injectAggregator](container.Global) })
+
+	cleanup()
+}
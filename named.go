@@ -0,0 +1,93 @@
+package container
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Identifies a set of bindings by their bound type and the name they were
+// bound with. Unnamed bindings have an empty name.
+type bindingKey struct {
+	bindingType reflect.Type
+	name        string
+}
+
+// Formats the key for use in an error, e.g. "*sql.DB(name=replica)"
+func (key bindingKey) String() string {
+	if key.name == "" {
+		return key.bindingType.String()
+	}
+	return fmt.Sprintf("%v(name=%v)", key.bindingType, key.name)
+}
+
+// Binds under a name, so multiple implementations of the same bound type can
+// coexist and be resolved explicitly. Named bindings are only resolved by the
+// Named resolve functions, or by resolver arguments given the same name with
+// WithArgName.
+func WithName(name string) BindOption {
+	return func(b *binding) {
+		b.name = name
+	}
+}
+
+// Resolves the resolver argument at the index, starting from 0, from bindings
+// bound with the name instead of unnamed bindings. Slice arguments receive
+// every concrete bound with the name.
+func WithArgName(index int, name string) BindOption {
+	return func(b *binding) {
+		if b.argNames == nil {
+			b.argNames = make(map[int]string)
+		}
+		b.argNames[index] = name
+	}
+}
+
+// Attempts to resolve and return all concretes bound to the provided type
+// with the name as a slice. Uses the global container instance.
+func ResolveAllNamed[T any](name string) ([]T, error) {
+	return ResolveAllNamedInstance[T](Global, name)
+}
+
+// Attempts to resolve and return all concretes bound to the provided type
+// with the name as a slice. Uses the provided container instance.
+func ResolveAllNamedInstance[T any](container *Container, name string) ([]T, error) {
+	return resolveAllTyped[T](container, resolution{}, name)
+}
+
+// Attempts to resolve and return all concretes bound to the provided type
+// with the name as a slice. Scoped bindings are resolved against the provided
+// scope.
+func ResolveAllNamedScope[T any](scope *Scope, name string) ([]T, error) {
+	return resolveAllTyped[T](scope.container, resolution{scope: scope}, name)
+}
+
+// Resolves a single concrete bound to the provided type with the name. If
+// multiple resolvers were bound, the concrete from the most recent one is
+// returned. Uses the global container instance.
+func ResolveNamed[T any](name string) (T, error) {
+	return ResolveNamedInstance[T](Global, name)
+}
+
+// Resolves a single concrete bound to the provided type with the name. If
+// multiple resolvers were bound, the concrete from the most recent one is
+// returned. Uses the provided container instance.
+func ResolveNamedInstance[T any](container *Container, name string) (T, error) {
+	resolvedInstances, err := ResolveAllNamedInstance[T](container, name)
+	if err != nil {
+		return *new(T), err
+	}
+
+	return resolvedInstances[len(resolvedInstances)-1], nil
+}
+
+// Resolves a single concrete bound to the provided type with the name. If
+// multiple resolvers were bound, the concrete from the most recent one is
+// returned. Scoped bindings are resolved against the provided scope.
+func ResolveNamedScope[T any](scope *Scope, name string) (T, error) {
+	resolvedInstances, err := ResolveAllNamedScope[T](scope, name)
+	if err != nil {
+		return *new(T), err
+	}
+
+	return resolvedInstances[len(resolvedInstances)-1], nil
+}
@@ -0,0 +1,261 @@
+package container_test
+
+import (
+	"testing"
+
+	"github.com/gobros/container"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNamedBindsCoexist(t *testing.T) {
+	// Given
+	setup()
+
+	err := container.Bind[PrimaryIDGiver](NewTestStruct1, container.WithName("primary"))
+	assert.NoError(t, err)
+	err = container.Bind[PrimaryIDGiver](NewTestStruct2, container.WithName("replica"))
+	assert.NoError(t, err)
+
+	// When
+	primary, primaryErr := container.ResolveNamed[PrimaryIDGiver]("primary")
+	replica, replicaErr := container.ResolveNamed[PrimaryIDGiver]("replica")
+
+	// Then
+	assert.NoError(t, primaryErr)
+	assert.NoError(t, replicaErr)
+	assert.Equal(t, TestStruct1Name, primary.GivePrimaryID().Name)
+	assert.Equal(t, TestStruct2Name, replica.GivePrimaryID().Name)
+
+	cleanup()
+}
+
+func TestNamedSeparateFromUnnamed(t *testing.T) {
+	// Given
+	setup()
+
+	err := container.Bind[PrimaryIDGiver](NewTestStruct1, container.WithName("primary"))
+	assert.NoError(t, err)
+
+	// When
+	unnamed, unnamedErr := container.Resolve[PrimaryIDGiver]()
+	missing, missingErr := container.ResolveNamed[PrimaryIDGiver]("replica")
+
+	// Then
+	assert.Nil(t, unnamed)
+	assert.ErrorIs(t, unnamedErr, container.ErrNotBound)
+	assert.Nil(t, missing)
+	assert.ErrorIs(t, missingErr, container.ErrNotBound)
+	assert.Contains(t, missingErr.Error(), "(name=replica)")
+	var notBound *container.NotBoundError
+	assert.ErrorAs(t, missingErr, &notBound)
+	assert.Equal(t, "replica", notBound.Name)
+
+	cleanup()
+}
+
+func TestNamedResolveAll(t *testing.T) {
+	// Given
+	setup()
+
+	err := container.Bind[PrimaryIDGiver](NewTestStruct1, container.WithName("pool"))
+	assert.NoError(t, err)
+	err = container.Bind[PrimaryIDGiver](NewTestStruct2, container.WithName("pool"))
+	assert.NoError(t, err)
+	err = container.Bind[PrimaryIDGiver](NewTestStruct2)
+	assert.NoError(t, err)
+
+	// When
+	pool, poolErr := container.ResolveAllNamedInstance[PrimaryIDGiver](container.Global, "pool")
+	unnamed, unnamedErr := container.ResolveAll[PrimaryIDGiver]()
+
+	// Then
+	assert.NoError(t, poolErr)
+	assert.NoError(t, unnamedErr)
+	assert.Len(t, pool, 2)
+	assert.Len(t, unnamed, 1)
+	assert.Same(t, pool[1], unnamed[0])
+
+	cleanup()
+}
+
+func TestNamedResolverArgument(t *testing.T) {
+	// Given
+	setup()
+
+	err := container.Bind[PrimaryIDGiver](NewTestStruct1, container.WithName("internal"))
+	assert.NoError(t, err)
+	err = container.Bind[SecondaryIDGiver](NewTestStruct1, container.WithName("internal"))
+	assert.NoError(t, err)
+	err = container.Bind[SecondaryIDGiver](NewTestStruct2, container.WithName("external"))
+	assert.NoError(t, err)
+	err = container.Bind[IDAggregator](NewTestIDAggregatorStruct,
+		container.WithArgName(0, "internal"),
+		container.WithArgName(1, "external"))
+	assert.NoError(t, err)
+
+	// When
+	agg, err := container.Resolve[IDAggregator]()
+
+	// Then
+	assert.NoError(t, err)
+	assert.Len(t, agg.GivePrimaryIDs(), 1)
+	assert.Equal(t, TestStruct1Name, agg.GivePrimaryIDs()[0].Name)
+	assert.Equal(t, TestStruct2Name, agg.GiveSecondaryID().Name)
+	assert.NoError(t, container.Global.Validate())
+
+	cleanup()
+}
+
+func TestNamedSameResolverDifferentArgNames(t *testing.T) {
+	// Given
+	setup()
+
+	container.MustBind[SecondaryIDGiver](NewTestStruct1, container.WithName("primary"))
+	container.MustBind[SecondaryIDGiver](NewTestStruct2, container.WithName("replica"))
+	container.MustBind[IDAggregator](NewTestIDAggregatorStruct,
+		container.WithName("primary"), container.WithArgName(1, "primary"))
+	container.MustBind[IDAggregator](NewTestIDAggregatorStruct,
+		container.WithName("replica"), container.WithArgName(1, "replica"))
+	container.MustBind[PrimaryIDGiver](NewTestStruct1)
+	container.MustBind[*TestStruct1](NewTestStruct1)
+
+	// When
+	primary, primaryErr := container.ResolveNamed[IDAggregator]("primary")
+	replica, replicaErr := container.ResolveNamed[IDAggregator]("replica")
+	shared := container.MustResolve[PrimaryIDGiver]()
+	sharedAgain := container.MustResolve[*TestStruct1]()
+
+	// Then
+	assert.NoError(t, primaryErr)
+	assert.NoError(t, replicaErr)
+	assert.NotSame(t, primary, replica)
+	assert.Equal(t, TestStruct1Name, primary.GiveSecondaryID().Name)
+	assert.Equal(t, TestStruct2Name, replica.GiveSecondaryID().Name)
+	assert.Same(t, shared, sharedAgain)
+
+	cleanup()
+}
+
+func TestNamedResolverArgumentMissing(t *testing.T) {
+	// Given
+	setup()
+
+	err := container.Bind[SecondaryIDGiver](NewTestStruct1)
+	assert.NoError(t, err)
+	err = container.Bind[IDAggregator](NewTestIDAggregatorStruct, container.WithArgName(1, "external"))
+	assert.NoError(t, err)
+
+	// When
+	agg, resolveErr := container.Resolve[IDAggregator]()
+	validateErr := container.Global.Validate()
+
+	// Then
+	assert.Nil(t, agg)
+	assert.ErrorIs(t, resolveErr, container.ErrNotBound)
+	assert.Contains(t, resolveErr.Error(), "SecondaryIDGiver(name=external)")
+	assert.ErrorIs(t, validateErr, container.ErrNotBound)
+
+	cleanup()
+}
+
+func TestNamedWithArgNameOutOfRange(t *testing.T) {
+	// Given
+	setup()
+
+	// When
+	err := container.Bind[IDAggregator](NewTestIDAggregatorStruct, container.WithArgName(2, "external"))
+
+	// Then
+	assert.ErrorIs(t, err, container.ErrInvalidResolver)
+
+	cleanup()
+}
+
+func TestNamedSameTypeNoFalseCycle(t *testing.T) {
+	// Given
+	setup()
+
+	type repo struct {
+		inner *repo
+	}
+	err := container.Bind[*repo](func() *repo {
+		return &repo{}
+	}, container.WithName("db"))
+	assert.NoError(t, err)
+	err = container.Bind[*repo](func(inner *repo) *repo {
+		return &repo{inner: inner}
+	}, container.WithName("cached"), container.WithArgName(0, "db"))
+	assert.NoError(t, err)
+
+	// When
+	cached, err := container.ResolveNamed[*repo]("cached")
+
+	// Then
+	assert.NoError(t, err)
+	assert.NotNil(t, cached.inner)
+	assert.NoError(t, container.Global.Validate())
+
+	cleanup()
+}
+
+func TestNamedCycle(t *testing.T) {
+	// Given
+	setup()
+
+	err := container.Bind[*cycleRepo](func(r *cycleRepo) *cycleRepo {
+		return &cycleRepo{}
+	}, container.WithName("a"), container.WithArgName(0, "b"))
+	assert.NoError(t, err)
+	err = container.Bind[*cycleRepo](func(r *cycleRepo) *cycleRepo {
+		return &cycleRepo{}
+	}, container.WithName("b"), container.WithArgName(0, "a"))
+	assert.NoError(t, err)
+
+	// When
+	_, err = container.ResolveNamed[*cycleRepo]("a")
+
+	// Then
+	assert.ErrorIs(t, err, container.ErrDependencyCycle)
+	assert.Contains(t, err.Error(), "*container_test.cycleRepo(name=a) -> *container_test.cycleRepo(name=b) -> *container_test.cycleRepo(name=a)")
+
+	cleanup()
+}
+
+func TestNamedValue(t *testing.T) {
+	// Given
+	setup()
+
+	value := &TestStruct1{InstanceId: 42}
+	err := container.BindValue[PrimaryIDGiver](value, container.WithName("fake"))
+	assert.NoError(t, err)
+
+	// When
+	resolved, err := container.ResolveNamed[PrimaryIDGiver]("fake")
+
+	// Then
+	assert.NoError(t, err)
+	assert.Same(t, value, resolved)
+
+	cleanup()
+}
+
+func TestNamedScoped(t *testing.T) {
+	// Given
+	setup()
+
+	err := container.Bind[PrimaryIDGiver](NewTestStruct1, container.WithName("request"), container.WithLifetime(container.Scoped))
+	assert.NoError(t, err)
+	scope := container.Global.NewScope()
+
+	// When
+	first, firstErr := container.ResolveNamedScope[PrimaryIDGiver](scope, "request")
+	all, allErr := container.ResolveAllNamedScope[PrimaryIDGiver](scope, "request")
+
+	// Then
+	assert.NoError(t, firstErr)
+	assert.NoError(t, allErr)
+	assert.Same(t, first, all[0])
+	assert.Equal(t, 1, Str1InstanceNumber)
+
+	cleanup()
+}
@@ -0,0 +1,244 @@
+package container
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+	"sync/atomic"
+	"time"
+)
+
+// Told about everything a container binds and resolves, for logging, metrics
+// or tracing. Methods are called synchronously on the goroutine doing the bind
+// or resolve, possibly from several goroutines at once, so they should be
+// quick and safe for concurrent use.
+type Observer interface {
+	// Called after a binding is added to the container
+	Bound(event BindEvent)
+	// Called before a binding is resolved
+	ResolveStarted(event ResolveEvent)
+	// Called after a binding is resolved, whether or not it succeeded
+	ResolveFinished(event ResolveEvent)
+}
+
+// Describes a binding that was added to a container
+type BindEvent struct {
+	// The bound type
+	Type reflect.Type
+	// The name the binding was bound with, empty if unnamed
+	Name string
+	// The resolver that was bound. Values and structs are bound with a
+	// generated resolver.
+	Resolver reflect.Value
+	// The lifetime the resolver was bound with
+	Lifetime Lifetime
+	// The module that provided the binding, empty if it was bound directly
+	Module string
+	// Whether the same resolver or value was already bound, and was replaced
+	// to take precedence
+	Override bool
+}
+
+// Describes a single binding being resolved. Resolving a binding that has
+// dependencies resolves them first, each with an event of their own.
+type ResolveEvent struct {
+	// Unique to this resolve of the binding
+	ID uint64
+	// The ID of the resolve that depends on this one, 0 if it was resolved
+	// directly
+	ParentID uint64
+	// The number of resolves that depend on this one, transitively. 0 if it
+	// was resolved directly.
+	Depth int
+	// The bound type being resolved
+	Type reflect.Type
+	// The name of the binding being resolved, empty if unnamed
+	Name string
+	// The resolver being resolved
+	Resolver reflect.Value
+	// The lifetime the resolver was bound with
+	Lifetime Lifetime
+	// When the resolve started
+	Start time.Time
+	// How long the resolve took, including resolving dependencies. Only set
+	// once finished.
+	Duration time.Duration
+	// Whether the concrete came from a cache or was a value, rather than the
+	// resolver being called. Only set once finished.
+	Cached bool
+	// The error the resolve failed with, nil if it succeeded. Only set once
+	// finished.
+	Err error
+	// The value the resolver panicked with, nil if it didn't panic. Only set
+	// once finished.
+	Panic any
+}
+
+// IDs handed out to resolves. Shared by every container so IDs stay unique
+// across parents and children.
+var resolveIDs atomic.Uint64
+
+// Sets the observer told about binds and resolves in the container. Child
+// containers without an observer of their own use their parent's. Pass nil to
+// stop observing.
+func (container *Container) SetObserver(observer Observer) {
+	if observer == nil {
+		container.observer.Store(nil)
+		return
+	}
+	container.observer.Store(&observer)
+}
+
+// Returns the observer for the container, or nil if there isn't one
+func (container *Container) getObserver() Observer {
+	for current := container; current != nil; current = current.parent {
+		if observer := current.observer.Load(); observer != nil {
+			return *observer
+		}
+	}
+	return nil
+}
+
+// Tells the observer about a binding that was added, if there is an observer
+func (container *Container) observeBind(bindingType reflect.Type, bound *binding, overridden bool) {
+	observer := container.getObserver()
+	if observer == nil {
+		return
+	}
+
+	event := BindEvent{
+		Type:     bindingType,
+		Name:     bound.name,
+		Resolver: bound.boundResolver(),
+		Lifetime: bound.lifetime,
+		Override: overridden,
+	}
+	if bound.module != nil {
+		event.Module = bound.module.name
+	}
+	observer.Bound(event)
+}
+
+// Resolves a binding, telling the observer when it starts and finishes
+func observeResolve(observer Observer, key bindingKey, bound *binding, container *Container, res resolution) (any, error) {
+	event := ResolveEvent{
+		ID:       resolveIDs.Add(1),
+		ParentID: res.resolveID,
+		Depth:    len(res.path) - 1,
+		Type:     key.bindingType,
+		Name:     key.name,
+		Resolver: bound.boundResolver(),
+		Lifetime: bound.lifetime,
+		Start:    time.Now(),
+	}
+	observer.ResolveStarted(event)
+
+	res.resolveID = event.ID
+	constructed := false
+	instance, err := resolveLifetime(key, bound, container, res, &constructed)
+
+	event.Duration = time.Since(event.Start)
+	event.Cached = !constructed
+	event.Err = err
+	// Only panics from this resolver, dependencies report their own
+	if resolverErr, ok := err.(*ResolverError); ok && resolverErr.Resolver == bound.boundResolver() {
+		event.Panic = resolverErr.Panic
+	}
+	observer.ResolveFinished(event)
+
+	return instance, err
+}
+
+// Combines observers so they can all be set on a container. Each is told about
+// every event in the order they were given.
+func MultiObserver(observers ...Observer) Observer {
+	return multiObserver(append([]Observer(nil), observers...))
+}
+
+// An Observer that passes events on to several others
+type multiObserver []Observer
+
+func (m multiObserver) Bound(event BindEvent) {
+	for _, observer := range m {
+		observer.Bound(event)
+	}
+}
+
+func (m multiObserver) ResolveStarted(event ResolveEvent) {
+	for _, observer := range m {
+		observer.ResolveStarted(event)
+	}
+}
+
+func (m multiObserver) ResolveFinished(event ResolveEvent) {
+	for _, observer := range m {
+		observer.ResolveFinished(event)
+	}
+}
+
+// An Observer that logs to a slog.Logger. Binds and successful resolves are
+// logged at debug level, failed resolves at error level. Nothing is logged
+// when resolves start.
+type SlogObserver struct {
+	logger *slog.Logger
+}
+
+var _ Observer = &SlogObserver{}
+
+// Creates an observer that logs to the logger. Uses slog.Default if logger is
+// nil.
+func NewSlogObserver(logger *slog.Logger) *SlogObserver {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &SlogObserver{logger: logger}
+}
+
+func (o *SlogObserver) Bound(event BindEvent) {
+	ctx := context.Background()
+	if !o.logger.Enabled(ctx, slog.LevelDebug) {
+		return
+	}
+
+	o.logger.LogAttrs(ctx, slog.LevelDebug, "container bind",
+		slog.String("type", event.Type.String()),
+		slog.String("name", event.Name),
+		slog.String("lifetime", event.Lifetime.String()),
+		slog.String("module", event.Module),
+		slog.Bool("override", event.Override),
+	)
+}
+
+func (o *SlogObserver) ResolveStarted(event ResolveEvent) {}
+
+func (o *SlogObserver) ResolveFinished(event ResolveEvent) {
+	ctx := context.Background()
+	level := slog.LevelDebug
+	if event.Err != nil {
+		level = slog.LevelError
+	}
+	if !o.logger.Enabled(ctx, level) {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("type", event.Type.String()),
+		slog.String("name", event.Name),
+		slog.String("lifetime", event.Lifetime.String()),
+		slog.Uint64("id", event.ID),
+		slog.Uint64("parent_id", event.ParentID),
+		slog.Int("depth", event.Depth),
+		slog.Duration("duration", event.Duration),
+		slog.Bool("cached", event.Cached),
+	}
+	if event.Err == nil {
+		o.logger.LogAttrs(ctx, level, "container resolve", attrs...)
+		return
+	}
+
+	attrs = append(attrs, slog.Any("error", event.Err))
+	if event.Panic != nil {
+		attrs = append(attrs, slog.Any("panic", event.Panic))
+	}
+	o.logger.LogAttrs(ctx, level, "container resolve failed", attrs...)
+}
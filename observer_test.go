@@ -0,0 +1,183 @@
+package container_test
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"sync"
+	"testing"
+
+	"github.com/gobros/container"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObserverBind(t *testing.T) {
+	// Given
+	c := &container.Container{}
+	observer := &recordingObserver{}
+	c.SetObserver(observer)
+
+	// When
+	container.MustBindInstance[PrimaryIDGiver](c, NewTestStruct1)
+	container.MustBindInstance[PrimaryIDGiver](c, NewTestStruct1, container.WithName("named"))
+	container.MustBindInstance[PrimaryIDGiver](c, NewTestStruct1, container.WithLifetime(container.Transient))
+	container.MustInstallInstance(c, container.NewModule("ids", container.Provide[SecondaryIDGiver](NewTestStruct2)))
+
+	// Then
+	assert.Len(t, observer.binds, 4)
+	assert.False(t, observer.binds[0].Override)
+	assert.Equal(t, "named", observer.binds[1].Name)
+	assert.True(t, observer.binds[2].Override)
+	assert.Equal(t, container.Transient, observer.binds[2].Lifetime)
+	assert.Equal(t, "ids", observer.binds[3].Module)
+}
+
+func TestObserverResolve(t *testing.T) {
+	// Given
+	c := &container.Container{}
+	container.MustBindInstance[PrimaryIDGiver](c, NewTestStruct1)
+	container.MustBindValueInstance[SecondaryIDGiver](c, &TestStruct2{})
+	container.MustBindInstance[IDAggregator](c, NewTestIDAggregatorStruct, container.WithLifetime(container.Transient))
+	observer := &recordingObserver{}
+	c.SetObserver(observer)
+
+	// When
+	container.MustResolveInstance[IDAggregator](c)
+	container.MustResolveInstance[IDAggregator](c)
+
+	// Then
+	assert.Len(t, observer.started, 6)
+	assert.Len(t, observer.finished, 6)
+
+	// Dependencies finish before what depends on them
+	primary, secondary, aggregator := observer.finished[0], observer.finished[1], observer.finished[2]
+	assert.Equal(t, observer.started[0].ID, aggregator.ID)
+	assert.Equal(t, uint64(0), aggregator.ParentID)
+	assert.Equal(t, 0, aggregator.Depth)
+	assert.False(t, aggregator.Cached)
+	assert.Equal(t, aggregator.ID, primary.ParentID)
+	assert.Equal(t, 1, primary.Depth)
+	assert.False(t, primary.Cached)
+	assert.Equal(t, aggregator.ID, secondary.ParentID)
+	assert.True(t, secondary.Cached)
+	assert.GreaterOrEqual(t, aggregator.Duration, primary.Duration)
+
+	// Second time around the singleton is cached but the transient isn't
+	assert.True(t, observer.finished[3].Cached)
+	assert.False(t, observer.finished[5].Cached)
+	assert.NotEqual(t, aggregator.ID, observer.finished[5].ID)
+}
+
+func TestObserverErrors(t *testing.T) {
+	// Given
+	c := &container.Container{}
+	container.MustBindInstance[PrimaryIDGiver](c, func() *TestStruct1 {
+		panic("primary did a bad!")
+	})
+	container.MustBindInstance[SecondaryIDGiver](c, func() (*TestStruct2, error) {
+		return nil, errors.New("secondary did a bad!")
+	})
+	container.MustBindInstance[IDAggregator](c, NewTestIDAggregatorStruct)
+	observer := &recordingObserver{}
+	c.SetObserver(observer)
+
+	// When
+	_, primaryErr := container.ResolveInstance[IDAggregator](c)
+	_, secondaryErr := container.ResolveInstance[SecondaryIDGiver](c)
+
+	// Then
+	assert.Error(t, primaryErr)
+	assert.Error(t, secondaryErr)
+	assert.Len(t, observer.finished, 3)
+	assert.ErrorIs(t, observer.finished[0].Err, container.ErrResolverPanicked)
+	assert.Equal(t, "primary did a bad!", observer.finished[0].Panic)
+	assert.ErrorIs(t, observer.finished[1].Err, container.ErrResolverPanicked)
+	assert.Nil(t, observer.finished[1].Panic)
+	assert.EqualError(t, errors.Unwrap(observer.finished[2].Err), "secondary did a bad!")
+	assert.Nil(t, observer.finished[2].Panic)
+}
+
+func TestObserverChildAndRemoval(t *testing.T) {
+	// Given
+	parent := &container.Container{}
+	observer := &recordingObserver{}
+	parent.SetObserver(observer)
+	child := parent.NewChild()
+	container.MustBindInstance[PrimaryIDGiver](child, NewTestStruct1)
+
+	// When
+	container.MustResolveInstance[PrimaryIDGiver](child)
+	parent.SetObserver(nil)
+	container.MustBindInstance[SecondaryIDGiver](child, NewTestStruct2)
+	container.MustResolveInstance[SecondaryIDGiver](child)
+
+	// Then
+	assert.Len(t, observer.binds, 1)
+	assert.Len(t, observer.finished, 1)
+}
+
+func TestSlogObserver(t *testing.T) {
+	// Given
+	var buf bytes.Buffer
+	c := &container.Container{}
+	c.SetObserver(container.NewSlogObserver(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))))
+	container.MustBindInstance[PrimaryIDGiver](c, NewTestStruct1)
+	container.MustBindInstance[SecondaryIDGiver](c, func() *TestStruct2 {
+		panic("secondary did a bad!")
+	})
+
+	// When
+	container.MustResolveInstance[PrimaryIDGiver](c)
+	_, err := container.ResolveInstance[SecondaryIDGiver](c)
+
+	// Then
+	assert.Error(t, err)
+	logged := buf.String()
+	assert.Contains(t, logged, `level=DEBUG msg="container bind" type=container_test.PrimaryIDGiver`)
+	assert.Contains(t, logged, `level=DEBUG msg="container resolve" type=container_test.PrimaryIDGiver`)
+	assert.Contains(t, logged, `level=ERROR msg="container resolve failed" type=container_test.SecondaryIDGiver`)
+	assert.Contains(t, logged, `panic="secondary did a bad!"`)
+}
+
+func TestSlogObserverLevel(t *testing.T) {
+	// Given
+	var buf bytes.Buffer
+	c := &container.Container{}
+	c.SetObserver(container.NewSlogObserver(slog.New(slog.NewTextHandler(&buf, nil))))
+	container.MustBindInstance[PrimaryIDGiver](c, NewTestStruct1)
+
+	// When
+	container.MustResolveInstance[PrimaryIDGiver](c)
+	_, err := container.ResolveInstance[SecondaryIDGiver](c)
+
+	// Then
+	assert.Error(t, err)
+	assert.Empty(t, buf.String())
+	assert.NotNil(t, container.NewSlogObserver(nil))
+}
+
+// Test observer that records every event
+type recordingObserver struct {
+	lock     sync.Mutex
+	binds    []container.BindEvent
+	started  []container.ResolveEvent
+	finished []container.ResolveEvent
+}
+
+func (o *recordingObserver) Bound(event container.BindEvent) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	o.binds = append(o.binds, event)
+}
+
+func (o *recordingObserver) ResolveStarted(event container.ResolveEvent) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	o.started = append(o.started, event)
+}
+
+func (o *recordingObserver) ResolveFinished(event container.ResolveEvent) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	o.finished = append(o.finished, event)
+}
@@ -0,0 +1,66 @@
+package container
+
+import (
+	"reflect"
+)
+
+// A resolver argument that's resolved if something is bound to T, rather than
+// failing the resolve when nothing is. Present reports whether anything was
+// bound. If the binding exists but fails to resolve, the resolve still fails.
+// T must be a pointer or interface.
+type Optional[T any] struct {
+	// The most recently bound concrete, the zero value if nothing is bound
+	Value T
+	// Whether anything was bound to T
+	Present bool
+}
+
+// Implemented by every Optional type, so they can be recognised and filled in
+// without knowing T
+type optionalDependency interface {
+	// Returns T
+	dependencyType() reflect.Type
+	// Sets the value and marks it present
+	setDependency(value reflect.Value)
+}
+
+var optionalDependencyType = reflect.TypeOf((*optionalDependency)(nil)).Elem()
+
+func (o *Optional[T]) dependencyType() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+func (o *Optional[T]) setDependency(value reflect.Value) {
+	reflect.ValueOf(&o.Value).Elem().Set(value)
+	o.Present = true
+}
+
+// Returns true if the type is an Optional
+func isOptionalType(argType reflect.Type) bool {
+	return argType.Kind() == reflect.Struct && reflect.PointerTo(argType).Implements(optionalDependencyType)
+}
+
+// Returns the T of an Optional type
+func optionalElem(optionalType reflect.Type) reflect.Type {
+	return reflect.New(optionalType).Interface().(optionalDependency).dependencyType()
+}
+
+// Returns an Optional of the type holding the value
+func newOptional(optionalType reflect.Type, value reflect.Value) reflect.Value {
+	optional := reflect.New(optionalType)
+	optional.Interface().(optionalDependency).setDependency(value)
+	return optional.Elem()
+}
+
+// Marks the resolver argument at the index, starting from 0, as optional. If
+// nothing is bound to it, the resolver receives the zero value instead of the
+// resolve failing. Use an Optional argument to tell whether anything was
+// bound.
+func WithOptionalArg(index int) BindOption {
+	return func(b *binding) {
+		if b.argOptional == nil {
+			b.argOptional = make(map[int]bool)
+		}
+		b.argOptional[index] = true
+	}
+}
@@ -0,0 +1,192 @@
+package container_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gobros/container"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithOptionalArgNothingBound(t *testing.T) {
+	// Given
+	setup()
+
+	var received PrimaryIDGiver = &TestStruct1{}
+	container.MustBind[SecondaryIDGiver](func(prim PrimaryIDGiver) *TestStruct2 {
+		received = prim
+		return NewTestStruct2()
+	}, container.WithOptionalArg(0))
+
+	// When
+	_, err := container.Resolve[SecondaryIDGiver]()
+
+	// Then
+	assert.NoError(t, err)
+	assert.Nil(t, received)
+	assert.NoError(t, container.Global.Validate())
+
+	cleanup()
+}
+
+func TestWithOptionalArgBound(t *testing.T) {
+	// Given
+	setup()
+
+	container.MustBind[PrimaryIDGiver](NewTestStruct1)
+	var received PrimaryIDGiver
+	container.MustBind[SecondaryIDGiver](func(prim PrimaryIDGiver) *TestStruct2 {
+		received = prim
+		return NewTestStruct2()
+	}, container.WithOptionalArg(0))
+
+	// When
+	_, err := container.Resolve[SecondaryIDGiver]()
+
+	// Then
+	assert.NoError(t, err)
+	assert.Same(t, container.MustResolve[PrimaryIDGiver](), received)
+
+	cleanup()
+}
+
+func TestWithOptionalArgOutOfRange(t *testing.T) {
+	// Given
+	setup()
+
+	// When
+	err := container.Bind[PrimaryIDGiver](NewTestStruct1, container.WithOptionalArg(0))
+
+	// Then
+	assert.ErrorIs(t, err, container.ErrInvalidResolver)
+
+	cleanup()
+}
+
+func TestOptionalArg(t *testing.T) {
+	// Given
+	setup()
+
+	var absent, present container.Optional[PrimaryIDGiver]
+	container.MustBind[SecondaryIDGiver](func(prim container.Optional[PrimaryIDGiver]) *TestStruct2 {
+		absent = prim
+		return NewTestStruct2()
+	})
+	container.MustBind[IDAggregator](func(prim container.Optional[PrimaryIDGiver]) *TestIDAggregatorStruct {
+		present = prim
+		return &TestIDAggregatorStruct{}
+	})
+	container.MustResolve[SecondaryIDGiver]()
+	container.MustBind[PrimaryIDGiver](NewTestStruct1)
+
+	// When
+	_, err := container.Resolve[IDAggregator]()
+
+	// Then
+	assert.NoError(t, err)
+	assert.False(t, absent.Present)
+	assert.Nil(t, absent.Value)
+	assert.True(t, present.Present)
+	assert.Same(t, container.MustResolve[PrimaryIDGiver](), present.Value)
+
+	cleanup()
+}
+
+func TestOptionalArgResolverError(t *testing.T) {
+	// Given
+	setup()
+
+	container.MustBind[PrimaryIDGiver](func() (*TestStruct1, error) {
+		return nil, errors.New("primary did a bad!")
+	})
+	container.MustBind[SecondaryIDGiver](func(prim container.Optional[PrimaryIDGiver]) *TestStruct2 {
+		return NewTestStruct2()
+	})
+
+	// When
+	_, err := container.Resolve[SecondaryIDGiver]()
+
+	// Then
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "primary did a bad!")
+
+	cleanup()
+}
+
+func TestOptionalArgInvalidElem(t *testing.T) {
+	// Given
+	setup()
+
+	// When
+	err := container.Bind[SecondaryIDGiver](func(id container.Optional[ID]) *TestStruct2 {
+		return NewTestStruct2()
+	})
+
+	// Then
+	assert.ErrorIs(t, err, container.ErrInvalidResolver)
+
+	cleanup()
+}
+
+func TestOptionalInvoke(t *testing.T) {
+	// Given
+	setup()
+
+	var received container.Optional[PrimaryIDGiver]
+
+	// When
+	err := container.Invoke(func(prim container.Optional[PrimaryIDGiver]) {
+		received = prim
+	})
+
+	// Then
+	assert.NoError(t, err)
+	assert.False(t, received.Present)
+
+	cleanup()
+}
+
+func TestOptionalInjectField(t *testing.T) {
+	// Given
+	setup()
+
+	container.MustBind[PrimaryIDGiver](NewTestStruct1)
+	target := &optionalTarget{}
+
+	// When
+	err := container.Inject(target)
+
+	// Then
+	assert.NoError(t, err)
+	assert.True(t, target.Prim.Present)
+	assert.False(t, target.Secondary.Present)
+
+	cleanup()
+}
+
+func TestOptionalIntrospection(t *testing.T) {
+	// Given
+	setup()
+
+	container.MustBind[SecondaryIDGiver](func(prim container.Optional[PrimaryIDGiver]) *TestStruct2 {
+		return NewTestStruct2()
+	})
+
+	// When
+	bindings := container.Global.Bindings()
+
+	// Then
+	assert.Len(t, bindings, 1)
+	deps := bindings[0].Resolvers[0].Dependencies
+	assert.Len(t, deps, 1)
+	assert.True(t, deps[0].Optional)
+	assert.Equal(t, "container_test.PrimaryIDGiver", deps[0].Type.String())
+
+	cleanup()
+}
+
+// Test type with Optional fields to inject
+type optionalTarget struct {
+	Prim      container.Optional[PrimaryIDGiver]   `inject:""`
+	Secondary container.Optional[SecondaryIDGiver] `inject:""`
+}
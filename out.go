@@ -0,0 +1,203 @@
+package container
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// Embedded in a struct to make it a results struct. A resolver that returns a
+// results struct can be bound with BindResults, which binds each of its
+// exported fields to the field's type instead of binding the struct. The
+// resolver is only called once for every field resolved from it, respecting
+// its lifetime. Fields must be pointers or interfaces, and can be tagged with
+// the name to bind them with, e.g. `inject:"replica"`.
+//
+//	type ClientResults struct {
+//		container.Out
+//
+//		Client  *Client
+//		Health  HealthChecker `inject:"client"`
+//		Metrics MetricsCollector
+//	}
+//
+//	func NewClient(config *Config) (ClientResults, error)
+type Out struct{}
+
+var outType = reflect.TypeOf(Out{})
+
+// A field of a results struct bound as its own binding
+type resultField struct {
+	// The key the field is bound to
+	key bindingKey
+	// Returns the field from the results struct it's passed
+	resolver reflect.Value
+}
+
+// Fields built for results struct types, keyed by struct type. Shared by
+// every resolver returning the struct type, since their field bindings are
+// also identified by the resolver the fields are taken from.
+var resultFields sync.Map
+
+// Binds each field of the results struct returned by the resolver to the
+// field's type. The resolver is called once for all of them, and the results
+// are cached together according to its lifetime. Uses the global container
+// instance.
+func BindResults(resolver any, opts ...BindOption) error {
+	return BindResultsInstance(Global, resolver, opts...)
+}
+
+// Binds each field of the results struct returned by the resolver to the
+// field's type. The resolver is called once for all of them, and the results
+// are cached together according to its lifetime. Uses the provided container
+// instance.
+func BindResultsInstance(container *Container, resolver any, opts ...BindOption) error {
+	newBindings, err := newResultBindings(reflect.ValueOf(resolver), opts)
+	if err != nil {
+		return err
+	}
+
+	container.lock.Lock()
+	for idx, newBinding := range newBindings {
+		newBindings[idx].overridden = container.insertBinding(newBinding.bindingType, newBinding.binding)
+	}
+	container.lock.Unlock()
+
+	for _, newBinding := range newBindings {
+		container.observeBind(newBinding.bindingType, newBinding.binding, newBinding.overridden)
+	}
+
+	return nil
+}
+
+// Creates the bindings for a resolver returning a results struct. The first
+// binds the resolver to the results struct type, and the rest bind each
+// field to a resolver that takes the results struct and returns the field.
+func newResultBindings(resolverValue reflect.Value, opts []BindOption) ([]installedBinding, error) {
+	if err := validateResultsResolver(resolverValue); err != nil {
+		return nil, &ValidationError{Resolver: resolverValue, Err: err}
+	}
+	resultsType := resolverValue.Type().Out(0)
+
+	resultsBinding := &binding{resolver: resolverValue, lifetime: Singleton}
+	if hasParamStructs(resolverValue.Type()) {
+		resultsBinding = newParamBinding(resolverValue)
+	}
+	if err := prepareBinding(resultsType, resultsBinding, opts); err != nil {
+		return nil, err
+	}
+	if resultsBinding.name != "" {
+		err := fmt.Errorf("resolver error, results can't be bound with a name, tag the fields of (%v) instead", resultsType)
+		return nil, &ValidationError{Type: resultsType, Resolver: resolverValue, Err: err}
+	}
+
+	// Validated along with the resolver
+	fields, _ := getResultFields(resultsType)
+
+	newBindings := []installedBinding{{bindingType: resultsType, binding: resultsBinding}}
+	for _, field := range fields {
+		fieldBinding := &binding{
+			resolver: field.resolver,
+			lifetime: resultsBinding.lifetime,
+			name:     field.key.name,
+			original: resultsBinding.boundResolver(),
+			results:  resultsBinding,
+		}
+		newBindings = append(newBindings, installedBinding{bindingType: field.key.bindingType, binding: fieldBinding})
+	}
+
+	return newBindings, nil
+}
+
+// Validates that a resolver returns a results struct, optionally along with
+// an error, and that its arguments can be resolved
+func validateResultsResolver(resolverValue reflect.Value) error {
+	if resolverValue.Kind() != reflect.Func {
+		return fmt.Errorf("resolver error, resolver must be a function")
+	}
+
+	resolverType := resolverValue.Type()
+	if resolverType.NumOut() == 0 || !isResultsStruct(resolverType.Out(0)) {
+		return fmt.Errorf("resolver error, resolver must return a results struct as it's first return")
+	}
+	if resolverType.NumOut() >= 2 && !resolverType.Out(1).Implements(reflect.TypeOf((*error)(nil)).Elem()) {
+		return fmt.Errorf("resolver error, resolvers with two or more parameters must return an error as the second parameter")
+	}
+	if _, err := getResultFields(resolverType.Out(0)); err != nil {
+		return fmt.Errorf("resolver error, %w", err)
+	}
+
+	for i := 0; i < resolverType.NumIn(); i++ {
+		paramType := resolverType.In(i)
+		if !isResolvableParam(paramType) && !isParamStruct(paramType) {
+			return fmt.Errorf("resolver error, resolver input parameters must all be of type pointer, interface, slice, Optional, Lazy, func() (T, error), or a parameter struct")
+		}
+	}
+	if err := validateParamStructs(resolverType); err != nil {
+		return fmt.Errorf("resolver error, %w", err)
+	}
+
+	return nil
+}
+
+// Returns true if the type is a results struct
+func isResultsStruct(resultsType reflect.Type) bool {
+	if resultsType.Kind() != reflect.Struct {
+		return false
+	}
+	for i := 0; i < resultsType.NumField(); i++ {
+		if field := resultsType.Field(i); field.Anonymous && field.Type == outType {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the fields of a results struct to be bound, creating their
+// resolvers the first time they're asked for. Fails if a field can't be
+// bound.
+func getResultFields(resultsType reflect.Type) ([]resultField, error) {
+	if fields, ok := resultFields.Load(resultsType); ok {
+		return fields.([]resultField), nil
+	}
+
+	var fields []resultField
+	seen := make(map[bindingKey]string)
+	for i := 0; i < resultsType.NumField(); i++ {
+		structField := resultsType.Field(i)
+		if structField.Anonymous && structField.Type == outType {
+			continue
+		}
+
+		if !structField.IsExported() {
+			return nil, fmt.Errorf("field (%v) of results struct (%v) must be exported to be bound", structField.Name, resultsType)
+		}
+		if structField.Type.Kind() != reflect.Ptr && structField.Type.Kind() != reflect.Interface {
+			return nil, fmt.Errorf("field (%v) of results struct (%v) must be a pointer or interface to be bound", structField.Name, resultsType)
+		}
+		tag := structField.Tag.Get(injectTag)
+		if strings.Contains(tag, ",") {
+			return nil, fmt.Errorf("field (%v) of results struct (%v) can only be tagged with a name", structField.Name, resultsType)
+		}
+
+		key := bindingKey{bindingType: structField.Type, name: tag}
+		if other, ok := seen[key]; ok {
+			return nil, fmt.Errorf("field (%v) of results struct (%v) is bound to (%v) the same as field (%v), name one of them", structField.Name, resultsType, key, other)
+		}
+		seen[key] = structField.Name
+
+		index := i
+		resolverType := reflect.FuncOf([]reflect.Type{resultsType}, []reflect.Type{structField.Type}, false)
+		resolver := reflect.MakeFunc(resolverType, func(args []reflect.Value) []reflect.Value {
+			return []reflect.Value{args[0].Field(index)}
+		})
+		fields = append(fields, resultField{key: key, resolver: resolver})
+	}
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("results struct (%v) has no fields to bind", resultsType)
+	}
+
+	loaded, _ := resultFields.LoadOrStore(resultsType, fields)
+	return loaded.([]resultField), nil
+}
@@ -0,0 +1,282 @@
+package container_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/gobros/container"
+	"github.com/stretchr/testify/assert"
+)
+
+type idGiverResults struct {
+	container.Out
+
+	Primary   PrimaryIDGiver
+	Secondary SecondaryIDGiver
+	Named     *TestStruct2 `inject:"named"`
+}
+
+var resultsCalls = 0
+
+func newIDGiverResults() idGiverResults {
+	resultsCalls++
+	return idGiverResults{
+		Primary:   NewTestStruct1(),
+		Secondary: NewTestStruct1(),
+		Named:     NewTestStruct2(),
+	}
+}
+
+func TestBindResults(t *testing.T) {
+	// Given
+	setup()
+	resultsCalls = 0
+
+	// When
+	err := container.BindResults(newIDGiverResults)
+	primary, primaryErr := container.Resolve[PrimaryIDGiver]()
+	secondary, secondaryErr := container.Resolve[SecondaryIDGiver]()
+	named, namedErr := container.ResolveNamed[*TestStruct2]("named")
+	again := container.MustResolve[PrimaryIDGiver]()
+
+	// Then
+	assert.NoError(t, err)
+	assert.NoError(t, primaryErr)
+	assert.NoError(t, secondaryErr)
+	assert.NoError(t, namedErr)
+	assert.Equal(t, ID{Name: TestStruct1Name, Number: 1}, primary.GivePrimaryID())
+	assert.Equal(t, ID{Name: TestStruct1Name, Number: 2}, secondary.GiveSecondaryID())
+	assert.Equal(t, ID{Name: TestStruct2Name, Number: 1}, named.GivePrimaryID())
+	assert.Same(t, primary, again)
+	assert.Equal(t, 1, resultsCalls)
+	assert.False(t, container.Has[*TestStruct2]())
+
+	cleanup()
+}
+
+func newOtherIDGiverResults() idGiverResults {
+	return idGiverResults{
+		Primary:   NewTestStruct2(),
+		Secondary: NewTestStruct2(),
+		Named:     NewTestStruct2(),
+	}
+}
+
+func TestBindResultsLastBindWins(t *testing.T) {
+	// Given
+	setup()
+
+	container.MustBindResults(newIDGiverResults)
+	first := container.MustResolve[PrimaryIDGiver]()
+
+	// When
+	container.MustBindResults(newOtherIDGiverResults)
+	primary := container.MustResolve[PrimaryIDGiver]()
+	results := container.MustResolve[idGiverResults]()
+
+	// Then
+	assert.Equal(t, TestStruct1Name, first.GivePrimaryID().Name)
+	assert.Equal(t, TestStruct2Name, primary.GivePrimaryID().Name)
+	assert.Same(t, results.Primary, primary)
+
+	cleanup()
+}
+
+func TestBindResultsResolveAll(t *testing.T) {
+	// Given
+	setup()
+
+	container.MustBindResults(newIDGiverResults)
+	container.MustBindResults(newOtherIDGiverResults)
+
+	// When
+	results := container.MustResolveAll[idGiverResults]()
+	primaries := container.MustResolveAll[PrimaryIDGiver]()
+
+	// Then
+	assert.Len(t, results, 2)
+	assert.Len(t, primaries, 2)
+	assert.Equal(t, TestStruct1Name, primaries[0].GivePrimaryID().Name)
+	assert.Equal(t, TestStruct2Name, primaries[1].GivePrimaryID().Name)
+	assert.Same(t, results[0].Primary, primaries[0])
+	assert.Same(t, results[1].Primary, primaries[1])
+
+	cleanup()
+}
+
+func TestBindResultsScoped(t *testing.T) {
+	// Given
+	setup()
+	resultsCalls = 0
+
+	container.MustBindResults(newIDGiverResults, container.WithLifetime(container.Scoped))
+	first := container.Global.NewScope()
+	second := container.Global.NewScope()
+
+	// When
+	firstPrimary := container.MustResolveScope[PrimaryIDGiver](first)
+	firstSecondary := container.MustResolveScope[SecondaryIDGiver](first)
+	secondPrimary := container.MustResolveScope[PrimaryIDGiver](second)
+
+	// Then
+	assert.NotSame(t, firstPrimary, firstSecondary)
+	assert.NotSame(t, firstPrimary, secondPrimary)
+	assert.Equal(t, 2, resultsCalls)
+
+	assert.NoError(t, first.Close())
+	assert.NoError(t, second.Close())
+	cleanup()
+}
+
+func TestBindResultsTransient(t *testing.T) {
+	// Given
+	setup()
+	resultsCalls = 0
+
+	container.MustBindResults(newIDGiverResults, container.WithLifetime(container.Transient))
+
+	// When
+	first := container.MustResolve[PrimaryIDGiver]()
+	second := container.MustResolve[PrimaryIDGiver]()
+
+	// Then
+	assert.NotSame(t, first, second)
+	assert.Equal(t, 2, resultsCalls)
+
+	cleanup()
+}
+
+type aggregatorResults struct {
+	container.Out
+
+	Aggregator IDAggregator
+	Stoppable  *stoppableC
+}
+
+func TestBindResultsWithDependencies(t *testing.T) {
+	// Given
+	setup()
+
+	var stopped []string
+	container.MustBind[PrimaryIDGiver](NewTestStruct1)
+	container.MustBind[SecondaryIDGiver](NewTestStruct2)
+	container.MustBindResults(func(primaries []PrimaryIDGiver, secondary SecondaryIDGiver) (aggregatorResults, error) {
+		return aggregatorResults{
+			Aggregator: NewTestIDAggregatorStruct(primaries, secondary),
+			Stoppable:  &stoppableC{stopped: &stopped},
+		}, nil
+	})
+
+	// When
+	aggregator, err := container.Resolve[IDAggregator]()
+	container.MustResolve[*stoppableC]()
+	closeErr := container.Global.Close(context.Background())
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, []ID{{Name: TestStruct1Name, Number: 1}}, aggregator.GivePrimaryIDs())
+	assert.Equal(t, ID{Name: TestStruct2Name, Number: 1}, aggregator.GiveSecondaryID())
+	assert.NoError(t, closeErr)
+	assert.Equal(t, []string{"c"}, stopped)
+
+	cleanup()
+}
+
+func TestBindResultsResolverError(t *testing.T) {
+	// Given
+	setup()
+
+	resolverErr := errors.New("failed")
+	container.MustBindResults(func() (idGiverResults, error) {
+		return idGiverResults{}, resolverErr
+	})
+
+	// When
+	_, err := container.Resolve[PrimaryIDGiver]()
+
+	// Then
+	assert.ErrorIs(t, err, resolverErr)
+	var resolveErr *container.ResolverError
+	assert.ErrorAs(t, err, &resolveErr)
+	assert.Equal(t, reflect.TypeOf(idGiverResults{}), resolveErr.Type)
+	assert.Equal(t, []reflect.Type{
+		reflect.TypeOf((*PrimaryIDGiver)(nil)).Elem(),
+		reflect.TypeOf(idGiverResults{}),
+	}, resolveErr.Path)
+
+	cleanup()
+}
+
+func TestBindResultsMissingDependency(t *testing.T) {
+	// Given
+	setup()
+
+	container.MustBindResults(func(aggregator IDAggregator) idGiverResults {
+		return idGiverResults{}
+	})
+
+	// When
+	validateErr := container.Global.Validate()
+	info := container.Global.Bindings()
+
+	// Then
+	assert.ErrorIs(t, validateErr, container.ErrNotBound)
+	assert.Len(t, info, 4)
+	for _, binding := range info {
+		assert.Contains(t, binding.Resolvers[0].Function, "TestBindResultsMissingDependency")
+	}
+
+	cleanup()
+}
+
+func TestBindResultsInvalid(t *testing.T) {
+	// Given
+	setup()
+
+	type unexportedResults struct {
+		container.Out
+
+		primary PrimaryIDGiver
+	}
+	type valueResults struct {
+		container.Out
+
+		ID ID
+	}
+	type duplicateResults struct {
+		container.Out
+
+		First  PrimaryIDGiver
+		Second PrimaryIDGiver
+	}
+	type modifierResults struct {
+		container.Out
+
+		Primary PrimaryIDGiver `inject:",optional"`
+	}
+	type emptyResults struct {
+		container.Out
+	}
+
+	// When & Then
+	assert.ErrorIs(t, container.BindResults(NewTestStruct1), container.ErrInvalidResolver)
+	assert.ErrorIs(t, container.BindResults(func(int) idGiverResults { return idGiverResults{} }), container.ErrInvalidResolver)
+	assert.ErrorContains(t, container.BindResults(func() unexportedResults { return unexportedResults{} }),
+		"field (primary) of results struct (container_test.unexportedResults) must be exported")
+	assert.ErrorContains(t, container.BindResults(func() valueResults { return valueResults{} }),
+		"field (ID) of results struct (container_test.valueResults) must be a pointer or interface")
+	assert.ErrorContains(t, container.BindResults(func() duplicateResults { return duplicateResults{} }),
+		"field (Second) of results struct (container_test.duplicateResults) is bound to (container_test.PrimaryIDGiver) the same as field (First)")
+	assert.ErrorContains(t, container.BindResults(func() modifierResults { return modifierResults{} }),
+		"field (Primary) of results struct (container_test.modifierResults) can only be tagged with a name")
+	assert.ErrorContains(t, container.BindResults(func() emptyResults { return emptyResults{} }),
+		"results struct (container_test.emptyResults) has no fields to bind")
+	assert.ErrorContains(t, container.BindResults(newIDGiverResults, container.WithName("results")),
+		"results can't be bound with a name")
+	assert.Panics(t, func() { container.MustBindResults(NewTestStruct1) })
+	assert.Empty(t, container.Global.Bindings())
+
+	cleanup()
+}
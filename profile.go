@@ -0,0 +1,293 @@
+package container
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// An Observer that records a nested timeline of every resolve, to find what's
+// slowing down startup. Set it on a container with SetObserver, resolve, then
+// export the timeline with Report or WriteTrace. Safe for concurrent use.
+type Profiler struct {
+	lock sync.Mutex
+	// When the first resolve was recorded, trace timestamps are relative to it
+	start time.Time
+	// Resolves that weren't triggered by another resolve, in the order they
+	// started
+	roots []*ProfileSpan
+	// Resolves that haven't finished yet, keyed by ID
+	running map[uint64]*ProfileSpan
+	// Function names of resolvers, cached since looking them up is slow
+	functions map[reflect.Value]string
+}
+
+// A single resolve recorded by a Profiler
+type ProfileSpan struct {
+	// Unique to this resolve
+	ID uint64
+	// The ID of the resolve that triggered this one, 0 if it was resolved
+	// directly
+	ParentID uint64
+	// The bound type that was resolved
+	Type reflect.Type
+	// The name of the binding that was resolved, empty if unnamed
+	Name string
+	// The resolver's function name, empty for values and structs
+	Function string
+	// When the resolve started
+	Start time.Time
+	// How long the resolve took, including resolving dependencies
+	Total time.Duration
+	// How long the resolve took, not including resolving dependencies
+	Self time.Duration
+	// Whether the concrete came from a cache rather than the resolver being
+	// called
+	Cached bool
+	// The error the resolve failed with, nil if it succeeded
+	Err error
+	// The resolves triggered by this one, in the order they started
+	Children []*ProfileSpan
+}
+
+var _ Observer = &Profiler{}
+
+// Creates an empty profiler
+func NewProfiler() *Profiler {
+	return &Profiler{}
+}
+
+func (p *Profiler) Bound(event BindEvent) {}
+
+func (p *Profiler) ResolveStarted(event ResolveEvent) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.running == nil {
+		p.running = make(map[uint64]*ProfileSpan)
+		p.functions = make(map[reflect.Value]string)
+	}
+	if p.start.IsZero() {
+		p.start = event.Start
+	}
+
+	function, ok := p.functions[event.Resolver]
+	if !ok {
+		function = resolverFunctionName(event.Resolver)
+		p.functions[event.Resolver] = function
+	}
+
+	span := &ProfileSpan{
+		ID:       event.ID,
+		ParentID: event.ParentID,
+		Type:     event.Type,
+		Name:     event.Name,
+		Function: function,
+		Start:    event.Start,
+	}
+	if parent, ok := p.running[event.ParentID]; ok {
+		parent.Children = append(parent.Children, span)
+	} else {
+		p.roots = append(p.roots, span)
+	}
+	p.running[event.ID] = span
+}
+
+func (p *Profiler) ResolveFinished(event ResolveEvent) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	span, ok := p.running[event.ID]
+	if !ok {
+		return
+	}
+	delete(p.running, event.ID)
+
+	span.Total = event.Duration
+	span.Cached = event.Cached
+	span.Err = event.Err
+	span.Self = span.Total
+	for _, child := range span.Children {
+		span.Self -= child.Total
+	}
+}
+
+// Returns a copy of the resolves that weren't triggered by another resolve, in
+// the order they started. Their children hold the rest of the timeline.
+// Resolves that haven't finished yet have no durations.
+func (p *Profiler) Spans() []*ProfileSpan {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	return copySpans(p.roots)
+}
+
+// Deep copies the spans so they can be read while more are recorded. Expects
+// the profiler lock to be held.
+func copySpans(spans []*ProfileSpan) []*ProfileSpan {
+	if spans == nil {
+		return nil
+	}
+
+	copied := make([]*ProfileSpan, len(spans))
+	for idx, span := range spans {
+		spanCopy := *span
+		spanCopy.Children = copySpans(span.Children)
+		copied[idx] = &spanCopy
+	}
+	return copied
+}
+
+// Discards everything recorded so far
+func (p *Profiler) Reset() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.start = time.Time{}
+	p.roots = nil
+	p.running = nil
+	p.functions = nil
+}
+
+// A resolver's combined timings across every time it was called
+type profileEntry struct {
+	label string
+	self  time.Duration
+	total time.Duration
+	calls int
+}
+
+// Writes a table of the resolvers that took the longest, not including
+// resolving their dependencies. Resolvers called several times, such as
+// transients, are combined into one row. Cached resolves aren't counted. Only
+// the slowest limit resolvers are written, or all of them if limit is 0.
+func (p *Profiler) Report(w io.Writer, limit int) error {
+	entries := make(map[string]*profileEntry)
+	var walk func(spans []*ProfileSpan)
+	walk = func(spans []*ProfileSpan) {
+		for _, span := range spans {
+			walk(span.Children)
+			if span.Cached {
+				continue
+			}
+
+			label := span.label()
+			entry, ok := entries[label]
+			if !ok {
+				entry = &profileEntry{label: label}
+				entries[label] = entry
+			}
+			entry.self += span.Self
+			entry.total += span.Total
+			entry.calls++
+		}
+	}
+	walk(p.Spans())
+
+	sorted := make([]*profileEntry, 0, len(entries))
+	for _, entry := range entries {
+		sorted = append(sorted, entry)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].self != sorted[j].self {
+			return sorted[i].self > sorted[j].self
+		}
+		return sorted[i].label < sorted[j].label
+	})
+	if limit > 0 && len(sorted) > limit {
+		sorted = sorted[:limit]
+	}
+
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "SELF\tTOTAL\tCALLS\tRESOLVER")
+	for _, entry := range sorted {
+		fmt.Fprintf(table, "%v\t%v\t%d\t%v\n", entry.self, entry.total, entry.calls, entry.label)
+	}
+	return table.Flush()
+}
+
+// A single event in the Chrome trace_event format
+type traceEvent struct {
+	Name      string         `json:"name"`
+	Category  string         `json:"cat"`
+	Phase     string         `json:"ph"`
+	Timestamp float64        `json:"ts"`
+	Duration  float64        `json:"dur"`
+	ProcessID int            `json:"pid"`
+	ThreadID  uint64         `json:"tid"`
+	Args      map[string]any `json:"args,omitempty"`
+}
+
+// Writes the timeline as JSON in the Chrome trace_event format, which can be
+// opened in Perfetto or chrome://tracing. Each resolve that wasn't triggered by
+// another is drawn on its own track, with the resolves it triggered nested
+// below it.
+func (p *Profiler) WriteTrace(w io.Writer) error {
+	p.lock.Lock()
+	start := p.start
+	p.lock.Unlock()
+
+	events := []traceEvent{}
+	var walk func(spans []*ProfileSpan, track uint64)
+	walk = func(spans []*ProfileSpan, track uint64) {
+		for _, span := range spans {
+			args := map[string]any{
+				"id":     span.ID,
+				"cached": span.Cached,
+				"self":   span.Self.String(),
+			}
+			if span.Function != "" {
+				args["function"] = span.Function
+			}
+			if span.Err != nil {
+				args["error"] = span.Err.Error()
+			}
+			events = append(events, traceEvent{
+				Name:      span.label(),
+				Category:  "resolve",
+				Phase:     "X",
+				Timestamp: float64(span.Start.Sub(start).Nanoseconds()) / 1e3,
+				Duration:  float64(span.Total.Nanoseconds()) / 1e3,
+				ProcessID: 1,
+				ThreadID:  track,
+				Args:      args,
+			})
+			walk(span.Children, track)
+		}
+	}
+	for _, root := range p.Spans() {
+		walk([]*ProfileSpan{root}, root.ID)
+	}
+
+	return json.NewEncoder(w).Encode(map[string]any{
+		"traceEvents":     events,
+		"displayTimeUnit": "ms",
+	})
+}
+
+// Returns the text to identify the span's resolver by, e.g.
+// "*sql.DB(name=replica) main.OpenReplica"
+func (span *ProfileSpan) label() string {
+	label := bindingKey{bindingType: span.Type, name: span.Name}.String()
+	if span.Function == "" {
+		return label
+	}
+	return label + " " + span.Function
+}
+
+// Returns the resolver's function name, or an empty string for resolvers
+// generated for values and structs
+func resolverFunctionName(resolver reflect.Value) string {
+	fn := runtime.FuncForPC(resolver.Pointer())
+	if fn == nil || strings.HasPrefix(fn.Name(), "reflect.") {
+		return ""
+	}
+	return fn.Name()
+}
@@ -0,0 +1,158 @@
+package container_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gobros/container"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProfilerTimeline(t *testing.T) {
+	// Given
+	c, profiler := bindProfiled(t)
+
+	// When
+	container.MustResolveInstance[IDAggregator](c)
+	container.MustResolveInstance[IDAggregator](c)
+	spans := profiler.Spans()
+
+	// Then
+	assert.Len(t, spans, 2)
+
+	aggregator := spans[0]
+	assert.Equal(t, "container_test.IDAggregator", aggregator.Type.String())
+	assert.False(t, aggregator.Cached)
+	assert.Len(t, aggregator.Children, 2)
+	assert.GreaterOrEqual(t, aggregator.Self, 10*time.Millisecond)
+	assert.GreaterOrEqual(t, aggregator.Total, 30*time.Millisecond)
+	assert.Equal(t, aggregator.Total-aggregator.Children[0].Total-aggregator.Children[1].Total, aggregator.Self)
+
+	primary := aggregator.Children[0]
+	assert.Equal(t, aggregator.ID, primary.ParentID)
+	assert.GreaterOrEqual(t, primary.Self, 20*time.Millisecond)
+	assert.Empty(t, primary.Children)
+	assert.Contains(t, primary.Function, "bindProfiled")
+
+	secondary := aggregator.Children[1]
+	assert.Equal(t, "fixed", secondary.Name)
+	assert.Empty(t, secondary.Function)
+
+	assert.True(t, spans[1].Cached)
+	assert.Empty(t, spans[1].Children)
+}
+
+func TestProfilerReport(t *testing.T) {
+	// Given
+	c, profiler := bindProfiled(t)
+	container.MustResolveInstance[IDAggregator](c)
+	container.MustResolveInstance[IDAggregator](c)
+
+	// When
+	var full, limited bytes.Buffer
+	fullErr := profiler.Report(&full, 0)
+	limitedErr := profiler.Report(&limited, 1)
+
+	// Then
+	assert.NoError(t, fullErr)
+	assert.NoError(t, limitedErr)
+
+	lines := strings.Split(strings.TrimSpace(full.String()), "\n")
+	assert.Len(t, lines, 3)
+	assert.True(t, strings.HasPrefix(lines[0], "SELF"))
+	assert.Contains(t, lines[1], "container_test.PrimaryIDGiver")
+	assert.Contains(t, lines[2], "container_test.IDAggregator")
+	assert.NotContains(t, full.String(), "SecondaryIDGiver")
+
+	assert.Len(t, strings.Split(strings.TrimSpace(limited.String()), "\n"), 2)
+}
+
+func TestProfilerWriteTrace(t *testing.T) {
+	// Given
+	c, profiler := bindProfiled(t)
+	container.MustResolveInstance[IDAggregator](c)
+
+	// When
+	var buf bytes.Buffer
+	err := profiler.WriteTrace(&buf)
+
+	// Then
+	assert.NoError(t, err)
+
+	var trace struct {
+		TraceEvents []struct {
+			Name     string         `json:"name"`
+			Phase    string         `json:"ph"`
+			Start    float64        `json:"ts"`
+			Duration float64        `json:"dur"`
+			ThreadID uint64         `json:"tid"`
+			Args     map[string]any `json:"args"`
+		} `json:"traceEvents"`
+	}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &trace))
+	assert.Len(t, trace.TraceEvents, 3)
+	root := trace.TraceEvents[0]
+	assert.Equal(t, "X", root.Phase)
+	assert.Equal(t, float64(0), root.Start)
+	assert.GreaterOrEqual(t, root.Duration, float64(30000))
+	for _, event := range trace.TraceEvents[1:] {
+		assert.Equal(t, root.ThreadID, event.ThreadID)
+		assert.GreaterOrEqual(t, event.Start, root.Start)
+		assert.LessOrEqual(t, event.Start+event.Duration, root.Start+root.Duration)
+	}
+}
+
+func TestProfilerResetAndEmpty(t *testing.T) {
+	// Given
+	c, profiler := bindProfiled(t)
+	container.MustResolveInstance[IDAggregator](c)
+
+	// When
+	profiler.Reset()
+
+	// Then
+	assert.Empty(t, profiler.Spans())
+	var buf bytes.Buffer
+	assert.NoError(t, profiler.WriteTrace(&buf))
+	assert.Contains(t, buf.String(), `"traceEvents":[]`)
+}
+
+func TestMultiObserver(t *testing.T) {
+	// Given
+	c := &container.Container{}
+	first, second := &recordingObserver{}, &recordingObserver{}
+	c.SetObserver(container.MultiObserver(first, second))
+
+	// When
+	container.MustBindInstance[PrimaryIDGiver](c, NewTestStruct1)
+	container.MustResolveInstance[PrimaryIDGiver](c)
+
+	// Then
+	for _, observer := range []*recordingObserver{first, second} {
+		assert.Len(t, observer.binds, 1)
+		assert.Len(t, observer.started, 1)
+		assert.Len(t, observer.finished, 1)
+	}
+}
+
+// Binds an aggregator that takes 10ms with a primary dependency that takes
+// 20ms and a named value, observed by a new profiler
+func bindProfiled(t *testing.T) (*container.Container, *container.Profiler) {
+	c := &container.Container{}
+	container.MustBindInstance[PrimaryIDGiver](c, func() *TestStruct1 {
+		time.Sleep(20 * time.Millisecond)
+		return &TestStruct1{}
+	})
+	container.MustBindValueInstance[SecondaryIDGiver](c, &TestStruct2{}, container.WithName("fixed"))
+	container.MustBindInstance[IDAggregator](c, func(prim []PrimaryIDGiver, sec SecondaryIDGiver) *TestIDAggregatorStruct {
+		time.Sleep(10 * time.Millisecond)
+		return &TestIDAggregatorStruct{}
+	}, container.WithArgName(1, "fixed"))
+
+	profiler := container.NewProfiler()
+	c.SetObserver(profiler)
+	return c, profiler
+}
@@ -0,0 +1,63 @@
+package container
+
+import (
+	"context"
+)
+
+// A unit of work, such as a single request or job, that Scoped bindings are
+// resolved against. Scoped bindings are resolved once per scope, everything
+// else falls back to the container it was created from. Close the scope when
+// the unit of work is done.
+type Scope struct {
+	container *Container
+	// Scoped concretes built in this scope
+	instanceCache
+	closed bool
+}
+
+// Creates a new scope that resolves against the container
+func (container *Container) NewScope() *Scope {
+	return &Scope{container: container}
+}
+
+// Discards every concrete built by the scope. Concretes are torn down in the
+// reverse order they were built in, the same way Container.Close does. Any
+// errors encountered are joined together. The scope can't be used to resolve
+// after it's closed.
+func (scope *Scope) Close() error {
+	scope.lock.Lock()
+	if scope.closed {
+		scope.lock.Unlock()
+		return nil
+	}
+	scope.closed = true
+	scope.lock.Unlock()
+
+	return stopConcretes(context.Background(), scope.reset())
+}
+
+// Returns true once the scope has been closed
+func (scope *Scope) isClosed() bool {
+	scope.lock.Lock()
+	defer scope.lock.Unlock()
+
+	return scope.closed
+}
+
+// Attempts to resolve and return all concretes bound to the provided type as
+// a slice. Scoped bindings are resolved against the provided scope.
+func ResolveAllScope[T any](scope *Scope) ([]T, error) {
+	return resolveAllTyped[T](scope.container, resolution{scope: scope}, "")
+}
+
+// Resolves a single concrete bound to the provided type. If multiple resolvers
+// were bound, the concrete from the most recent one is returned. Scoped
+// bindings are resolved against the provided scope.
+func ResolveScope[T any](scope *Scope) (T, error) {
+	resolvedInstances, err := ResolveAllScope[T](scope)
+	if err != nil {
+		return *new(T), err
+	}
+
+	return resolvedInstances[len(resolvedInstances)-1], nil
+}
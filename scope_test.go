@@ -0,0 +1,263 @@
+package container_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gobros/container"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScopedResolveSameScope(t *testing.T) {
+	// Given
+	setup()
+
+	err := container.Bind[PrimaryIDGiver](NewTestStruct1, container.WithLifetime(container.Scoped))
+	assert.NoError(t, err)
+	scope := container.Global.NewScope()
+
+	// When
+	first, firstErr := container.ResolveScope[PrimaryIDGiver](scope)
+	second, secondErr := container.ResolveScope[PrimaryIDGiver](scope)
+
+	// Then
+	assert.NoError(t, firstErr)
+	assert.NoError(t, secondErr)
+	assert.Same(t, first, second)
+	assert.Equal(t, 1, Str1InstanceNumber)
+
+	cleanup()
+}
+
+func TestScopedResolveDifferentScopes(t *testing.T) {
+	// Given
+	setup()
+
+	err := container.Bind[PrimaryIDGiver](NewTestStruct1, container.WithLifetime(container.Scoped))
+	assert.NoError(t, err)
+	scope1 := container.Global.NewScope()
+	scope2 := container.Global.NewScope()
+
+	// When
+	first, firstErr := container.ResolveScope[PrimaryIDGiver](scope1)
+	second, secondErr := container.ResolveScope[PrimaryIDGiver](scope2)
+
+	// Then
+	assert.NoError(t, firstErr)
+	assert.NoError(t, secondErr)
+	assert.NotSame(t, first, second)
+	assert.Equal(t, 2, Str1InstanceNumber)
+
+	cleanup()
+}
+
+func TestScopedFallsBackToSingletons(t *testing.T) {
+	// Given
+	setup()
+
+	err := container.Bind[SecondaryIDGiver](NewTestStruct1)
+	assert.NoError(t, err)
+	err = container.Bind[IDAggregator](NewTestIDAggregatorStruct, container.WithLifetime(container.Scoped))
+	assert.NoError(t, err)
+	scope1 := container.Global.NewScope()
+	scope2 := container.Global.NewScope()
+
+	// When
+	agg1, err1 := container.ResolveScope[IDAggregator](scope1)
+	agg2, err2 := container.ResolveScope[IDAggregator](scope2)
+	sec, secErr := container.Resolve[SecondaryIDGiver]()
+
+	// Then
+	assert.NoError(t, err1)
+	assert.NoError(t, err2)
+	assert.NoError(t, secErr)
+	assert.NotSame(t, agg1, agg2)
+	assert.Equal(t, 1, Str1InstanceNumber)
+	assert.Equal(t, sec.GiveSecondaryID(), agg1.GiveSecondaryID())
+	assert.Equal(t, sec.GiveSecondaryID(), agg2.GiveSecondaryID())
+
+	cleanup()
+}
+
+func TestScopedResolveOutsideScope(t *testing.T) {
+	// Given
+	setup()
+
+	err := container.Bind[PrimaryIDGiver](NewTestStruct1, container.WithLifetime(container.Scoped))
+	assert.NoError(t, err)
+
+	// When
+	val, err := container.Resolve[PrimaryIDGiver]()
+
+	// Then
+	assert.Nil(t, val)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "outside of a scope")
+	assert.Equal(t, 0, Str1InstanceNumber)
+
+	cleanup()
+}
+
+func TestSingletonCantCaptureScoped(t *testing.T) {
+	// Given
+	setup()
+
+	err := container.Bind[SecondaryIDGiver](NewTestStruct1, container.WithLifetime(container.Scoped))
+	assert.NoError(t, err)
+	err = container.Bind[IDAggregator](NewTestIDAggregatorStruct)
+	assert.NoError(t, err)
+	scope := container.Global.NewScope()
+
+	// When
+	val, err := container.ResolveScope[IDAggregator](scope)
+
+	// Then
+	assert.Nil(t, val)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "outside of a scope")
+
+	cleanup()
+}
+
+func TestTransientInScopeUsesScoped(t *testing.T) {
+	// Given
+	setup()
+
+	err := container.Bind[SecondaryIDGiver](NewTestStruct1, container.WithLifetime(container.Scoped))
+	assert.NoError(t, err)
+	err = container.Bind[IDAggregator](NewTestIDAggregatorStruct, container.WithLifetime(container.Transient))
+	assert.NoError(t, err)
+	scope := container.Global.NewScope()
+
+	// When
+	agg1, err1 := container.ResolveScope[IDAggregator](scope)
+	agg2, err2 := container.ResolveScope[IDAggregator](scope)
+
+	// Then
+	assert.NoError(t, err1)
+	assert.NoError(t, err2)
+	assert.NotSame(t, agg1, agg2)
+	assert.Equal(t, 1, Str1InstanceNumber)
+
+	cleanup()
+}
+
+func TestScopeCloseClosesInReverseOrder(t *testing.T) {
+	// Given
+	setup()
+
+	var closed []string
+	err := container.Bind[*closableA](func() *closableA {
+		return &closableA{closed: &closed}
+	}, container.WithLifetime(container.Scoped))
+	assert.NoError(t, err)
+	err = container.Bind[*closableB](func(a *closableA) *closableB {
+		return &closableB{closed: &closed}
+	}, container.WithLifetime(container.Scoped))
+	assert.NoError(t, err)
+	scope := container.Global.NewScope()
+	_, err = container.ResolveScope[*closableB](scope)
+	assert.NoError(t, err)
+
+	// When
+	err = scope.Close()
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"b", "a"}, closed)
+
+	cleanup()
+}
+
+func TestScopeCloseJoinsErrors(t *testing.T) {
+	// Given
+	setup()
+
+	var closed []string
+	err := container.Bind[*closableA](func() *closableA {
+		return &closableA{closed: &closed, err: errors.New("a did a bad!")}
+	}, container.WithLifetime(container.Scoped))
+	assert.NoError(t, err)
+	err = container.Bind[*closableB](func(a *closableA) *closableB {
+		return &closableB{closed: &closed, err: errors.New("b did a bad!")}
+	}, container.WithLifetime(container.Scoped))
+	assert.NoError(t, err)
+	scope := container.Global.NewScope()
+	_, err = container.ResolveScope[*closableB](scope)
+	assert.NoError(t, err)
+
+	// When
+	err = scope.Close()
+
+	// Then
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "a did a bad!")
+	assert.Contains(t, err.Error(), "b did a bad!")
+	assert.Equal(t, []string{"b", "a"}, closed)
+
+	cleanup()
+}
+
+func TestScopeCloseSkipsSingletons(t *testing.T) {
+	// Given
+	setup()
+
+	var closed []string
+	err := container.Bind[*closableA](func() *closableA {
+		return &closableA{closed: &closed}
+	})
+	assert.NoError(t, err)
+	scope := container.Global.NewScope()
+	_, err = container.ResolveScope[*closableA](scope)
+	assert.NoError(t, err)
+
+	// When
+	err = scope.Close()
+
+	// Then
+	assert.NoError(t, err)
+	assert.Empty(t, closed)
+
+	cleanup()
+}
+
+func TestResolveClosedScope(t *testing.T) {
+	// Given
+	setup()
+
+	err := container.Bind[PrimaryIDGiver](NewTestStruct1, container.WithLifetime(container.Scoped))
+	assert.NoError(t, err)
+	scope := container.Global.NewScope()
+	assert.NoError(t, scope.Close())
+
+	// When
+	val, err := container.ResolveScope[PrimaryIDGiver](scope)
+
+	// Then
+	assert.Nil(t, val)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "scope is closed")
+
+	cleanup()
+}
+
+// Test types that record when they're closed
+type closableA struct {
+	closed *[]string
+	err    error
+}
+
+func (c *closableA) Close() error {
+	*c.closed = append(*c.closed, "a")
+	return c.err
+}
+
+type closableB struct {
+	closed *[]string
+	err    error
+}
+
+func (c *closableB) Close() error {
+	*c.closed = append(*c.closed, "b")
+	return c.err
+}
@@ -0,0 +1,143 @@
+package container
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// Checks that every binding in the container can be resolved, without calling
+// any resolvers. Reports resolver arguments that have nothing bound to them,
+// dependency cycles, and singletons that depend on scoped bindings. Slice
+// arguments are always satisfiable, since they resolve to an empty slice when
+// nothing is bound. Only bindings bound directly in a child container are
+// checked, though they can depend on bindings in its parents. Returns every
+// problem found joined into a single error, or nil if there are none.
+func (container *Container) Validate() error {
+	container.lock.Lock()
+	defer container.lock.Unlock()
+
+	var errs []error
+	seenCycles := make(map[string]bool)
+	reachesScoped := make(map[bindingKey]bool)
+
+	for _, key := range container.sortedBindingKeys() {
+		for _, bound := range container.bindingToResolver[key] {
+			resolverType := bound.resolver.Type()
+
+			for i := 0; i < resolverType.NumIn(); i++ {
+				// Contexts come from the resolve rather than a binding
+				if bound.argIsContext(i) {
+					continue
+				}
+				argKey := bound.argKey(i)
+
+				if !bound.argIsAll(i) && !bound.argIsOptional(i) && !container.isBound(argKey, bound.module) {
+					notBound := &NotBoundError{Type: argKey.bindingType, Name: argKey.name, Path: pathTypes([]bindingKey{key, argKey})}
+					errs = append(errs, fmt.Errorf("resolver dependency error, failed to resolve dependency (%v) for interface (%v): %w", argKey, key, notBound))
+				}
+
+				if bound.lifetime == Singleton && container.reachesScoped(argKey, reachesScoped, map[bindingKey]bool{}) {
+					errs = append(errs, fmt.Errorf("resolver dependency error, singleton for interface (%v) depends on (%v): %w", key, argKey, ErrNoScope))
+				}
+			}
+
+			if cycle := container.findCycleFromResolver(bound, key); cycle != nil {
+				cycleKey := formatPath(rotateCycle(cycle))
+				if !seenCycles[cycleKey] {
+					seenCycles[cycleKey] = true
+					errs = append(errs, fmt.Errorf("failed to resolve for interface (%v), %w", cycle[0], newCycleError(cycle)))
+				}
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Returns true if resolving the bound type would resolve a scoped binding.
+// Results are memoized in known. Expects the container lock to be held.
+func (container *Container) reachesScoped(key bindingKey, known map[bindingKey]bool, visiting map[bindingKey]bool) bool {
+	if result, ok := known[key]; ok {
+		return result
+	}
+	// Cycles are reported separately
+	if visiting[key] {
+		return false
+	}
+	visiting[key] = true
+
+	// Bindings in a parent are resolved in the parent
+	if len(container.bindingToResolver[key]) == 0 && container.parent != nil {
+		known[key] = container.parent.lockedReachesScoped(key)
+		return known[key]
+	}
+
+	result := false
+	for _, bound := range container.bindingToResolver[key] {
+		if bound.lifetime == Scoped {
+			result = true
+			break
+		}
+		// Singletons never resolve their own dependencies in a scope, so
+		// anything scoped below them is reported against them instead
+		if bound.lifetime == Singleton {
+			continue
+		}
+		resolverType := bound.resolver.Type()
+		for i := 0; i < resolverType.NumIn() && !result; i++ {
+			result = !bound.argIsContext(i) && container.reachesScoped(bound.argKey(i), known, visiting)
+		}
+	}
+
+	known[key] = result
+	return result
+}
+
+// Same as reachesScoped, but locks the container
+func (container *Container) lockedReachesScoped(key bindingKey) bool {
+	container.lock.Lock()
+	defer container.lock.Unlock()
+
+	return container.reachesScoped(key, map[bindingKey]bool{}, map[bindingKey]bool{})
+}
+
+// Returns true if anything the module can depend on is bound to the bound
+// type in the container or any of its parents. Expects the container lock to
+// be held.
+func (container *Container) isBound(key bindingKey, module *Module) bool {
+	resolvers := container.bindingToResolver[key]
+	if len(resolvers) == 0 && container.parent != nil {
+		_, resolvers = container.parent.lookup(key)
+	}
+	visible, _ := visibleBindings(resolvers, module)
+	return len(visible) > 0
+}
+
+// Returns every binding key in the container, sorted by name so results are
+// stable. Expects the container lock to be held.
+func (container *Container) sortedBindingKeys() []bindingKey {
+	keys := make([]bindingKey, 0, len(container.bindingToResolver))
+	for key := range container.bindingToResolver {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].String() < keys[j].String()
+	})
+	return keys
+}
+
+// Rotates a cycle so it starts at the binding with the lowest name, so the
+// same cycle found from different starting points looks the same
+func rotateCycle(cycle []bindingKey) []bindingKey {
+	loop := cycle[:len(cycle)-1]
+	start := 0
+	for idx, cycleKey := range loop {
+		if cycleKey.String() < loop[start].String() {
+			start = idx
+		}
+	}
+
+	rotated := append(append([]bindingKey(nil), loop[start:]...), loop[:start]...)
+	return append(rotated, rotated[0])
+}
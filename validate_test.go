@@ -0,0 +1,131 @@
+package container_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/gobros/container"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateHappy(t *testing.T) {
+	// Given
+	setup()
+
+	container.MustBind[PrimaryIDGiver](NewTestStruct1)
+	container.MustBind[SecondaryIDGiver](NewTestStruct2)
+	container.MustBind[IDAggregator](NewTestIDAggregatorStruct)
+
+	// When
+	err := container.Global.Validate()
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, 0, Str1InstanceNumber)
+	assert.Equal(t, 0, Str2InstanceNumber)
+
+	cleanup()
+}
+
+func TestValidateEmpty(t *testing.T) {
+	// Given
+	c := &container.Container{}
+
+	// When
+	err := c.Validate()
+
+	// Then
+	assert.NoError(t, err)
+}
+
+func TestValidateEmptySliceSatisfiable(t *testing.T) {
+	// Given
+	setup()
+
+	container.MustBind[SecondaryIDGiver](NewTestStruct2)
+	container.MustBind[IDAggregator](NewTestIDAggregatorStruct)
+
+	// When
+	err := container.Global.Validate()
+
+	// Then
+	assert.NoError(t, err)
+
+	cleanup()
+}
+
+func TestValidateMissingDependencies(t *testing.T) {
+	// Given
+	setup()
+
+	container.MustBind[IDAggregator](NewTestIDAggregatorStruct)
+	container.MustBind[PrimaryIDGiver](func(server *cycleServer) *TestStruct1 {
+		return NewTestStruct1()
+	})
+
+	// When
+	err := container.Global.Validate()
+
+	// Then
+	assert.ErrorIs(t, err, container.ErrNotBound)
+	assert.Contains(t, err.Error(), "SecondaryIDGiver")
+	assert.Contains(t, err.Error(), "*container_test.cycleServer")
+	assert.Len(t, err.(interface{ Unwrap() []error }).Unwrap(), 2)
+
+	cleanup()
+}
+
+func TestValidateCycleReportedOnce(t *testing.T) {
+	// Given
+	setup()
+	bindCycle(t, container.Singleton)
+
+	// When
+	err := container.Global.Validate()
+
+	// Then
+	assert.ErrorIs(t, err, container.ErrDependencyCycle)
+	assert.Equal(t, 1, strings.Count(err.Error(), "dependency cycle detected"))
+	var cycleErr *container.CycleError
+	assert.True(t, errors.As(err, &cycleErr))
+	assert.Len(t, cycleErr.Path, 4)
+
+	cleanup()
+}
+
+func TestValidateSingletonDependsOnScoped(t *testing.T) {
+	// Given
+	setup()
+
+	container.MustBind[PrimaryIDGiver](NewTestStruct1, container.WithLifetime(container.Scoped))
+	container.MustBind[SecondaryIDGiver](func(prim PrimaryIDGiver) *TestStruct2 {
+		return NewTestStruct2()
+	}, container.WithLifetime(container.Transient))
+	container.MustBind[IDAggregator](NewTestIDAggregatorStruct)
+
+	// When
+	err := container.Global.Validate()
+
+	// Then
+	assert.ErrorIs(t, err, container.ErrNoScope)
+	assert.Contains(t, err.Error(), "singleton for interface (container_test.IDAggregator)")
+
+	cleanup()
+}
+
+func TestValidateScopedDependsOnScoped(t *testing.T) {
+	// Given
+	setup()
+
+	container.MustBind[SecondaryIDGiver](NewTestStruct2, container.WithLifetime(container.Scoped))
+	container.MustBind[IDAggregator](NewTestIDAggregatorStruct, container.WithLifetime(container.Scoped))
+
+	// When
+	err := container.Global.Validate()
+
+	// Then
+	assert.NoError(t, err)
+
+	cleanup()
+}
@@ -0,0 +1,66 @@
+package container
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Binds an existing value to a bound type, for things already built outside of
+// the container such as a config struct or a test fake. The value is returned
+// as is every time the bound type is resolved. Uses the global container
+// instance.
+func BindValue[T any](value any, opts ...BindOption) error {
+	return BindValueInstance[T](Global, value, opts...)
+}
+
+// Binds an existing value to a bound type, for things already built outside of
+// the container such as a config struct or a test fake. The value is returned
+// as is every time the bound type is resolved. Uses the provided container
+// instance.
+func BindValueInstance[T any](container *Container, value any, opts ...BindOption) error {
+	bindingType := getBindingType[T]()
+
+	newBinding, err := newValueBinding(bindingType, value)
+	if err != nil {
+		return err
+	}
+
+	return addBinding(container, bindingType, newBinding, opts)
+}
+
+// Creates a binding for a value, ensuring the value can be used as the bound
+// type
+func newValueBinding(bindingType reflect.Type, value any) (*binding, error) {
+	valueType := reflect.ValueOf(value)
+
+	err := validateValue(valueType, bindingType)
+	if err != nil {
+		return nil, &ValidationError{Type: bindingType, Resolver: valueType, Err: err}
+	}
+
+	// Wrap the value in a resolver so it can be treated like any other binding
+	resolverType := reflect.FuncOf(nil, []reflect.Type{valueType.Type()}, false)
+	resolver := reflect.MakeFunc(resolverType, func([]reflect.Value) []reflect.Value {
+		return []reflect.Value{valueType}
+	})
+
+	return &binding{resolver: resolver, lifetime: Singleton, value: valueType}, nil
+}
+
+// Validates that a value can be bound to a type
+func validateValue(valueType reflect.Value, genericType reflect.Type) error {
+	if !valueType.IsValid() {
+		return fmt.Errorf("resolver error, value must not be nil")
+	}
+	if genericType.Kind() != reflect.Ptr && genericType.Kind() != reflect.Interface {
+		return fmt.Errorf("resolver error, interface T must be a pointer or interface")
+	}
+	if genericType.Kind() == reflect.Interface && !valueType.Type().Implements(genericType) {
+		return fmt.Errorf("resolver error, value must be a type that implements the provided interface T")
+	}
+	if genericType.Kind() != reflect.Interface && !valueType.Type().AssignableTo(genericType) {
+		return fmt.Errorf("resolver error, value must be a type assignable to interface T")
+	}
+
+	return nil
+}
@@ -0,0 +1,205 @@
+package container_test
+
+import (
+	"testing"
+
+	"github.com/gobros/container"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBindValue(t *testing.T) {
+	// Given
+	setup()
+
+	value := &TestStruct1{InstanceId: 42}
+	err := container.BindValue[PrimaryIDGiver](value)
+	assert.NoError(t, err)
+
+	// When
+	first, firstErr := container.Resolve[PrimaryIDGiver]()
+	second, secondErr := container.Resolve[PrimaryIDGiver]()
+
+	// Then
+	assert.NoError(t, firstErr)
+	assert.NoError(t, secondErr)
+	assert.Same(t, value, first)
+	assert.Same(t, value, second)
+
+	cleanup()
+}
+
+func TestBindValuePointer(t *testing.T) {
+	// Given
+	setup()
+
+	type config struct {
+		Name string
+	}
+	value := &config{Name: "wirecat"}
+	err := container.BindValue[*config](value)
+	assert.NoError(t, err)
+
+	// When
+	resolved, err := container.Resolve[*config]()
+
+	// Then
+	assert.NoError(t, err)
+	assert.Same(t, value, resolved)
+
+	cleanup()
+}
+
+func TestBindValueAsDependency(t *testing.T) {
+	// Given
+	setup()
+
+	value := &TestStruct2{InstanceId: 42}
+	err := container.BindValue[SecondaryIDGiver](value)
+	assert.NoError(t, err)
+	err = container.Bind[IDAggregator](NewTestIDAggregatorStruct)
+	assert.NoError(t, err)
+
+	// When
+	agg, err := container.Resolve[IDAggregator]()
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, 42, agg.GiveSecondaryID().Number)
+	assert.NoError(t, container.Global.Validate())
+
+	cleanup()
+}
+
+func TestBindValueResolveAllOrdering(t *testing.T) {
+	// Given
+	setup()
+
+	value := &TestStruct2{InstanceId: 42}
+	err := container.BindValue[PrimaryIDGiver](value)
+	assert.NoError(t, err)
+	err = container.Bind[PrimaryIDGiver](NewTestStruct1)
+	assert.NoError(t, err)
+
+	// When
+	all, allErr := container.ResolveAll[PrimaryIDGiver]()
+	single, singleErr := container.Resolve[PrimaryIDGiver]()
+
+	// Then
+	assert.NoError(t, allErr)
+	assert.NoError(t, singleErr)
+	assert.Len(t, all, 2)
+	assert.Same(t, value, all[0])
+	assert.Equal(t, TestStruct1Name, single.GivePrimaryID().Name)
+
+	cleanup()
+}
+
+func TestBindValueOverride(t *testing.T) {
+	// Given
+	setup()
+
+	value := &TestStruct2{InstanceId: 42}
+	err := container.BindValue[PrimaryIDGiver](value)
+	assert.NoError(t, err)
+	err = container.Bind[PrimaryIDGiver](NewTestStruct1)
+	assert.NoError(t, err)
+	err = container.BindValue[PrimaryIDGiver](value)
+	assert.NoError(t, err)
+
+	// When
+	all, allErr := container.ResolveAll[PrimaryIDGiver]()
+	single, singleErr := container.Resolve[PrimaryIDGiver]()
+
+	// Then
+	assert.NoError(t, allErr)
+	assert.NoError(t, singleErr)
+	assert.Len(t, all, 2)
+	assert.Same(t, value, all[1])
+	assert.Same(t, value, single)
+
+	cleanup()
+}
+
+func TestBindValueDistinctValues(t *testing.T) {
+	// Given
+	setup()
+
+	err := container.BindValue[PrimaryIDGiver](&TestStruct1{InstanceId: 1})
+	assert.NoError(t, err)
+	err = container.BindValue[PrimaryIDGiver](&TestStruct1{InstanceId: 2})
+	assert.NoError(t, err)
+
+	// When
+	all, err := container.ResolveAll[PrimaryIDGiver]()
+
+	// Then
+	assert.NoError(t, err)
+	assert.Len(t, all, 2)
+
+	cleanup()
+}
+
+func TestBindValueErrorNil(t *testing.T) {
+	// Given
+	setup()
+
+	// When
+	err := container.BindValue[PrimaryIDGiver](nil)
+
+	// Then
+	assert.ErrorIs(t, err, container.ErrInvalidResolver)
+
+	cleanup()
+}
+
+func TestBindValueErrorInterfaceBad(t *testing.T) {
+	// Given
+	setup()
+
+	// When
+	err := container.BindValue[int](5)
+
+	// Then
+	assert.ErrorIs(t, err, container.ErrInvalidResolver)
+
+	cleanup()
+}
+
+func TestBindValueErrorDoesntImplementInterface(t *testing.T) {
+	// Given
+	setup()
+
+	// When
+	err := container.BindValue[PrimaryIDGiver](5)
+
+	// Then
+	assert.ErrorIs(t, err, container.ErrInvalidResolver)
+
+	cleanup()
+}
+
+func TestBindValueErrorIsntAssignable(t *testing.T) {
+	// Given
+	setup()
+
+	// When
+	err := container.BindValue[*int](&TestStruct1{})
+
+	// Then
+	assert.ErrorIs(t, err, container.ErrInvalidResolver)
+
+	cleanup()
+}
+
+func TestBindValueErrorNotSingleton(t *testing.T) {
+	// Given
+	setup()
+
+	// When
+	err := container.BindValue[PrimaryIDGiver](&TestStruct1{}, container.WithLifetime(container.Transient))
+
+	// Then
+	assert.ErrorIs(t, err, container.ErrInvalidResolver)
+
+	cleanup()
+}